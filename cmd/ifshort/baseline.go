@@ -0,0 +1,161 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/esimonov/ifshort/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+const baselineVersion = 1
+
+// baseline is the set of the known findings, which aren't reported.
+type baseline struct {
+	Version  int             `json:"version"`
+	Findings []baselineEntry `json:"findings"`
+}
+
+// baselineEntry is a known finding. The fields other than Fingerprint and Count are informational.
+type baselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Package     string `json:"package"`
+	Function    string `json:"function,omitempty"`
+	Rule        string `json:"rule"`
+	Variable    string `json:"variable,omitempty"`
+	// Count is the number of findings having the fingerprint, e.g. of the same declaration repeated in a function.
+	Count int `json:"count"`
+}
+
+// getFingerprint returns the fingerprint of the finding, which doesn't depend on its position,
+// so that the baseline survives the changes shifting the lines.
+// It's made of the package path, the function name, the variable name and the normalized text of the declaration.
+func getFingerprint(f finding, text string) string {
+	h := sha256.New()
+	for _, part := range []string{f.Rule, f.Package, f.function, f.Variable, strings.Join(strings.Fields(text), " ")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getEnclosingNodes returns the declaration of the variable for the short-syntax findings, or nil for the others,
+// and the name of the function the finding is in.
+func getEnclosingNodes(pkg *packages.Package, diag analysis.Diagnostic) (ast.Node, string) {
+	var file *ast.File
+	for _, f := range pkg.Syntax {
+		if pkg.Fset.File(f.Pos()) == pkg.Fset.File(diag.Pos) {
+			file = f
+		}
+	}
+	if file == nil {
+		return nil, ""
+	}
+
+	var node ast.Node
+	var function string
+
+	path, _ := astutil.PathEnclosingInterval(file, diag.Pos, diag.End)
+	for _, n := range path {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			if node == nil && diag.Category == analyzer.CategoryShortSyntax {
+				node = v
+			}
+		case *ast.FuncDecl:
+			function = v.Name.Name
+			if v.Recv != nil && len(v.Recv.List) != 0 {
+				function = types.ExprString(v.Recv.List[0].Type) + "." + function
+			}
+		}
+	}
+	return node, function
+}
+
+// writeBaseline writes the findings to the baseline file.
+func writeBaseline(name string, findings []finding) error {
+	entries := map[string]*baselineEntry{}
+	b := baseline{Version: baselineVersion, Findings: []baselineEntry{}}
+
+	for _, f := range findings {
+		if e, ok := entries[f.fingerprint]; ok {
+			e.Count++
+			continue
+		}
+
+		entries[f.fingerprint] = &baselineEntry{
+			Fingerprint: f.fingerprint,
+			Package:     f.Package,
+			Function:    f.function,
+			Rule:        f.Rule,
+			Variable:    f.Variable,
+			Count:       1,
+		}
+	}
+
+	for _, e := range entries {
+		b.Findings = append(b.Findings, *e)
+	}
+	sort.Slice(b.Findings, func(i, j int) bool {
+		a, c := b.Findings[i], b.Findings[j]
+		if a.Package != c.Package {
+			return a.Package < c.Package
+		}
+		if a.Function != c.Function {
+			return a.Function < c.Function
+		}
+		return a.Fingerprint < c.Fingerprint
+	})
+
+	data, err := json.MarshalIndent(b, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(data, '\n'), 0o644)
+}
+
+// readBaseline reads the baseline file, returning the number of the known findings per fingerprint.
+func readBaseline(name string) (map[string]int, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", name, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported version %d of baseline %s", b.Version, name)
+	}
+
+	known := map[string]int{}
+	for _, e := range b.Findings {
+		known[e.Fingerprint] += e.Count
+	}
+	return known, nil
+}
+
+// filterBaseline returns the findings that aren't in the baseline. If a fingerprint is found more times
+// than recorded in the baseline, the excess findings are considered new.
+func filterBaseline(findings []finding, known map[string]int) []finding {
+	var filtered []finding
+
+	for _, f := range findings {
+		if known[f.fingerprint] > 0 {
+			known[f.fingerprint]--
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
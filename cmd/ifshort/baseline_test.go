@@ -0,0 +1,51 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetFingerprint(t *testing.T) {
+	f := finding{Package: "p", Rule: "short-syntax", Variable: "v", function: "g"}
+
+	if getFingerprint(f, "v := f(a, b)") != getFingerprint(f, "v  :=  f(a,\n\tb)") {
+		t.Errorf("Expected the fingerprint not to depend on whitespace")
+	}
+	if getFingerprint(f, "v := f(a, b)") == getFingerprint(f, "v := f(b, a)") {
+		t.Errorf("Expected the fingerprint to depend on the declaration")
+	}
+
+	moved := f
+	moved.function = "h"
+	if getFingerprint(f, "v := f()") == getFingerprint(moved, "v := f()") {
+		t.Errorf("Expected the fingerprint to depend on the function")
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "baseline.json")
+
+	known := []finding{
+		{Package: "p", Rule: "short-syntax", Variable: "err", function: "g", fingerprint: "a"},
+		{Package: "p", Rule: "short-syntax", Variable: "err", function: "g", fingerprint: "a"},
+		{Package: "p", Rule: "short-syntax", Variable: "ok", function: "h", fingerprint: "b"},
+	}
+	if err := writeBaseline(name, known); err != nil {
+		t.Fatalf("Failed to write baseline: %s", err)
+	}
+
+	counts, err := readBaseline(name)
+	if err != nil {
+		t.Fatalf("Failed to read baseline: %s", err)
+	}
+	if counts["a"] != 2 || counts["b"] != 1 {
+		t.Fatalf("Expected counts 2 and 1, got %v", counts)
+	}
+
+	current := append(known, finding{fingerprint: "a", Line: 10}, finding{fingerprint: "c", Line: 20})
+
+	filtered := filterBaseline(current, counts)
+	if len(filtered) != 2 || filtered[0].Line != 10 || filtered[1].Line != 20 {
+		t.Errorf("Expected only the excess and the new findings, got %+v", filtered)
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/esimonov/ifshort/pkg/analyzer"
+	"gopkg.in/yaml.v3"
+)
+
+// configNames are the names of the configuration files, in the order of precedence.
+var configNames = []string{".ifshort.yaml", ".ifshort.yml", ".ifshort.toml"}
+
+// configFile is a configuration file, which applies to the packages in its directory and below it.
+// Besides the settings named the same as the flags, it has:
+//
+//   - "exclude", a list of globs matching the paths of the files that shouldn't be analyzed;
+//   - "overrides", a list of settings applied to the packages whose directory matches one of the globs in "paths".
+//
+// The globs are relative to the directory of the file. "*" matches any sequence of characters other than "/",
+// and "**" matches any sequence of path segments.
+type configFile struct {
+	dir       string
+	settings  map[string]any
+	exclude   []*regexp.Regexp
+	overrides []override
+}
+
+type override struct {
+	paths    []*regexp.Regexp
+	settings map[string]any
+}
+
+// configLoader finds the configuration files of the directories, caching them by directory.
+type configLoader map[string]*configFile
+
+// find returns the configuration file in the directory or the closest one above it, or nil if there's none.
+func (cl configLoader) find(dir string) (*configFile, error) {
+	if cf, ok := cl[dir]; ok {
+		return cf, nil
+	}
+
+	cf, err := readConfigFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if cf == nil {
+		if parent := filepath.Dir(dir); parent != dir {
+			if cf, err = cl.find(parent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cl[dir] = cf
+	return cf, nil
+}
+
+// readConfigFile reads the configuration file in the directory, if there's any.
+func readConfigFile(dir string) (*configFile, error) {
+	for _, name := range configNames {
+		path := filepath.Join(dir, name)
+
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var raw map[string]any
+		if strings.HasSuffix(name, ".toml") {
+			err = toml.Unmarshal(data, &raw)
+		} else {
+			err = yaml.Unmarshal(data, &raw)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		cf, err := newConfigFile(dir, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return cf, nil
+	}
+	return nil, nil
+}
+
+func newConfigFile(dir string, raw map[string]any) (*configFile, error) {
+	cf := &configFile{dir: dir, settings: raw}
+
+	exclude, err := popGlobs(raw, "exclude")
+	if err != nil {
+		return nil, err
+	}
+	cf.exclude = exclude
+
+	overrides, err := popList(raw, "overrides")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, el := range overrides {
+		settings, ok := el.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("overrides: expected a table, got %T", el)
+		}
+
+		paths, err := popGlobs(settings, "paths")
+		if err != nil {
+			return nil, fmt.Errorf("overrides: %w", err)
+		}
+		cf.overrides = append(cf.overrides, override{paths: paths, settings: settings})
+	}
+
+	// The settings are validated in advance, so that the errors are reported regardless of the packages.
+	cfg := analyzer.DefaultConfig()
+	if err := cfg.Decode(cf.settings); err != nil {
+		return nil, err
+	}
+	for _, o := range cf.overrides {
+		if err := cfg.Decode(o.settings); err != nil {
+			return nil, fmt.Errorf("overrides: %w", err)
+		}
+	}
+	return cf, nil
+}
+
+// popList removes the list from the settings and returns it.
+func popList(settings map[string]any, key string) ([]any, error) {
+	value, ok := settings[key]
+	if !ok {
+		return nil, nil
+	}
+	delete(settings, key)
+
+	switch v := value.(type) {
+	case []any:
+		return v, nil
+	case []map[string]any:
+		list := make([]any, 0, len(v))
+		for _, el := range v {
+			list = append(list, el)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("%s: expected a list, got %T", key, value)
+}
+
+// popGlobs removes the list of globs from the settings and returns them compiled.
+func popGlobs(settings map[string]any, key string) ([]*regexp.Regexp, error) {
+	list, err := popList(settings, key)
+	if err != nil {
+		return nil, err
+	}
+
+	globs := make([]*regexp.Regexp, 0, len(list))
+	for _, el := range list {
+		glob, ok := el.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a string, got %T", key, el)
+		}
+
+		re, err := globToRegexp(glob)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		globs = append(globs, re)
+	}
+	return globs, nil
+}
+
+// globToRegexp converts the glob to a regular expression matching the slash-separated relative paths.
+// A trailing "/**" also matches the directory itself.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case glob[i:] == "/**":
+			sb.WriteString("(?:/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			sb.WriteString("[^/]*")
+		case glob[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// apply applies the settings of the file to the configuration of the package in the directory:
+// the global ones first, and then the matching overrides in the order they're listed.
+func (cf *configFile) apply(cfg *analyzer.Config, pkgDir string) error {
+	if err := cfg.Decode(cf.settings); err != nil {
+		return err
+	}
+
+	rel := cf.rel(pkgDir)
+	for _, o := range cf.overrides {
+		if matchesAny(o.paths, rel) {
+			if err := cfg.Decode(o.settings); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(cf.exclude) == 0 {
+		return nil
+	}
+
+	// Exclusions are matched against the absolute paths of the files, like exclude-paths.
+	excludes := make([]string, 0, len(cf.exclude)+1)
+	if cfg.ExcludePaths != "" {
+		excludes = append(excludes, "(?:"+cfg.ExcludePaths+")")
+	}
+	prefix := regexp.QuoteMeta(filepath.ToSlash(cf.dir) + "/")
+	for _, re := range cf.exclude {
+		excludes = append(excludes, "(?:"+strings.Replace(re.String(), "^", "^"+prefix, 1)+")")
+	}
+	cfg.ExcludePaths = strings.Join(excludes, "|")
+	return nil
+}
+
+// rel returns the path relative to the directory of the file, or "." for the directory itself.
+func (cf *configFile) rel(path string) string {
+	rel, err := filepath.Rel(cf.dir, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func matchesAny(globs []*regexp.Regexp, path string) bool {
+	for _, re := range globs {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/esimonov/ifshort/pkg/analyzer"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		path    string
+		matches bool
+	}{
+		{glob: "legacy", path: "legacy", matches: true},
+		{glob: "legacy", path: "legacy/sub"},
+		{glob: "legacy/**", path: "legacy", matches: true},
+		{glob: "legacy/**", path: "legacy/sub/deep", matches: true},
+		{glob: "legacy/**", path: "legacyx"},
+		{glob: "**/gen", path: "gen", matches: true},
+		{glob: "**/gen", path: "a/b/gen", matches: true},
+		{glob: "*_mock.go", path: "a_mock.go", matches: true},
+		{glob: "*_mock.go", path: "sub/a_mock.go"},
+		{glob: "**/*_mock.go", path: "sub/a_mock.go", matches: true},
+		{glob: "v?.go", path: "v1.go", matches: true},
+		{glob: "a.go", path: "abgo"},
+	}
+
+	for _, tt := range tests {
+		re, err := globToRegexp(tt.glob)
+		if err != nil {
+			t.Fatalf("Failed to convert %q: %s", tt.glob, err)
+		}
+
+		if matches := re.MatchString(tt.path); matches != tt.matches {
+			t.Errorf("Expected %q matching %q to be %t", tt.glob, tt.path, tt.matches)
+		}
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	for name, content := range map[string]string{
+		".ifshort.yaml": `
+max-decl-chars: 50
+check-switch: true
+exclude:
+  - "**/*_mock.go"
+overrides:
+  - paths: ["legacy/**"]
+    max-decl-chars: 80
+    check-switch: false
+`,
+		".ifshort.toml": `
+max-decl-chars = 50
+check-switch = true
+exclude = ["**/*_mock.go"]
+
+[[overrides]]
+paths = ["legacy/**"]
+max-decl-chars = 80
+check-switch = false
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			legacy := filepath.Join(root, "legacy", "sub")
+
+			if err := os.MkdirAll(legacy, 0o755); err != nil {
+				t.Fatalf("Failed to create dir: %s", err)
+			}
+			if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %s", err)
+			}
+
+			loader := configLoader{}
+
+			cf, err := loader.find(legacy)
+			if err != nil {
+				t.Fatalf("Failed to find config: %s", err)
+			}
+			if cf == nil || cf.dir != root {
+				t.Fatalf("Expected the config in %s, got %+v", root, cf)
+			}
+
+			cfg := analyzer.DefaultConfig()
+			if err := cf.apply(&cfg, root); err != nil {
+				t.Fatalf("Failed to apply config: %s", err)
+			}
+			if cfg.MaxDeclChars != 50 || !cfg.CheckSwitch || cfg.MaxDeclLines != 1 {
+				t.Errorf("Expected the global settings, got %+v", cfg)
+			}
+
+			cfg = analyzer.DefaultConfig()
+			if err := cf.apply(&cfg, legacy); err != nil {
+				t.Fatalf("Failed to apply config: %s", err)
+			}
+			if cfg.MaxDeclChars != 80 || cfg.CheckSwitch {
+				t.Errorf("Expected the overridden settings, got %+v", cfg)
+			}
+
+			cfg.ExcludePaths = `\.pb\.go$`
+			if err := cf.apply(&cfg, legacy); err != nil {
+				t.Fatalf("Failed to apply config: %s", err)
+			}
+
+			for _, file := range []string{"/elsewhere/a.pb.go", filepath.Join(root, "legacy", "a_mock.go")} {
+				if !matchesExcludePaths(t, cfg.ExcludePaths, file) {
+					t.Errorf("Expected %s to be excluded by %s", file, cfg.ExcludePaths)
+				}
+			}
+			if file := "/elsewhere/a_mock.go"; matchesExcludePaths(t, cfg.ExcludePaths, file) {
+				t.Errorf("Expected %s not to be excluded by %s", file, cfg.ExcludePaths)
+			}
+		})
+	}
+}
+
+func TestConfigFile_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"max-decl-column: 50",
+		"max-decl-chars: fifty",
+		"exclude: a.go",
+		"overrides: [{paths: [legacy], check-switches: true}]",
+	} {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, ".ifshort.yaml"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %s", err)
+		}
+
+		if _, err := readConfigFile(dir); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+}
+
+func matchesExcludePaths(t *testing.T, excludePaths, file string) bool {
+	re, err := regexp.Compile(excludePaths)
+	if err != nil {
+		t.Fatalf("Invalid exclude-paths %s: %s", excludePaths, err)
+	}
+	return re.MatchString(filepath.ToSlash(file))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// changedLines maps the paths of the files in a diff, as they're written in it, to their added or modified lines.
+type changedLines map[string]map[int]bool
+
+// readDiff reads the unified diff from the file, or from stdin if name is "-".
+func readDiff(name string) (changedLines, error) {
+	if name == "-" {
+		return parseDiff(os.Stdin)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseDiff(f)
+}
+
+// parseDiff parses the unified diff, e.g. the output of git diff, collecting the lines
+// that are added or modified in the new versions of the files. The removed files are skipped.
+func parseDiff(r io.Reader) (changedLines, error) {
+	cl := changedLines{}
+
+	var lines map[int]bool
+	var line, left int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if left == 0 {
+			switch {
+			case strings.HasPrefix(text, "+++ "):
+				lines = nil
+
+				name := strings.TrimPrefix(text, "+++ ")
+				if i := strings.IndexByte(name, '\t'); i >= 0 {
+					name = name[:i]
+				}
+				if name == "/dev/null" {
+					continue
+				}
+
+				lines = map[int]bool{}
+				cl[strings.TrimPrefix(name, "b/")] = lines
+			case strings.HasPrefix(text, "@@ "):
+				match := hunkRe.FindStringSubmatch(text)
+				if match == nil {
+					return nil, fmt.Errorf("invalid hunk header %q", text)
+				}
+
+				line, _ = strconv.Atoi(match[1])
+				left = 1
+				if match[2] != "" {
+					left, _ = strconv.Atoi(match[2])
+				}
+			}
+			continue
+		}
+
+		// Within a hunk, the lines of the new version are either added or the context ones.
+		switch {
+		case strings.HasPrefix(text, "+"):
+			if lines != nil {
+				lines[line] = true
+			}
+			line++
+			left--
+		case strings.HasPrefix(text, " "), text == "":
+			line++
+			left--
+		}
+	}
+	return cl, scanner.Err()
+}
+
+// contains checks if any of the lines between from and to of the file is added or modified.
+// The file matches the path in the diff if it ends with it, as the paths in the diff are relative to the repository root.
+func (cl changedLines) contains(file string, from, to int) bool {
+	file = filepath.ToSlash(file)
+
+	for name, lines := range cl {
+		if file != name && !strings.HasSuffix(file, "/"+name) {
+			continue
+		}
+		for line := from; line <= to; line++ {
+			if lines[line] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterDiff returns the findings whose declaration or statement is on an added or modified line.
+func filterDiff(findings []finding, cl changedLines) []finding {
+	var filtered []finding
+
+	for _, f := range findings {
+		if cl.contains(f.File, f.Line, f.EndLine) ||
+			(f.Statement != nil && cl.contains(f.Statement.File, f.Statement.Line, f.Statement.Line)) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDiff = `diff --git a/p/p.go b/p/p.go
+index 0000000..1111111 100644
+--- a/p/p.go
++++ b/p/p.go
+@@ -3,4 +3,5 @@ func g() {
+ 	a := f()
+-	v := f()
++	v := f(1)
++	w := f()
+ 	if v != nil {
+ 	}
+@@ -20 +21 @@ func h() {
+-	x := f()
++	x := f(2)
+diff --git a/q.go b/q.go
+deleted file mode 100644
+--- a/q.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package p
+-
+`
+
+func TestParseDiff(t *testing.T) {
+	cl, err := parseDiff(strings.NewReader(testDiff))
+	if err != nil {
+		t.Fatalf("Failed to parse diff: %s", err)
+	}
+
+	if len(cl) != 1 {
+		t.Fatalf("Expected a single file, got %v", cl)
+	}
+
+	expected := map[int]bool{4: true, 5: true, 21: true}
+	lines := cl["p/p.go"]
+
+	if len(lines) != len(expected) {
+		t.Errorf("Expected lines %v, got %v", expected, lines)
+	}
+	for line := range expected {
+		if !lines[line] {
+			t.Errorf("Expected line %d to be changed, got %v", line, lines)
+		}
+	}
+}
+
+func TestFilterDiff(t *testing.T) {
+	cl, err := parseDiff(strings.NewReader(testDiff))
+	if err != nil {
+		t.Fatalf("Failed to parse diff: %s", err)
+	}
+
+	findings := []finding{
+		{File: "/repo/p/p.go", Line: 4, EndLine: 4},
+		{File: "/repo/p/p.go", Line: 3, EndLine: 3, Statement: &position{File: "/repo/p/p.go", Line: 5}},
+		{File: "/repo/p/p.go", Line: 6, EndLine: 6, Statement: &position{File: "/repo/p/p.go", Line: 7}},
+		{File: "/repo/other/p/p.go.orig", Line: 4, EndLine: 4},
+		{File: "/repo/pp/p.go", Line: 21, EndLine: 21},
+	}
+
+	filtered := filterDiff(findings, cl)
+	if len(filtered) != 2 || filtered[0].Line != 4 || filtered[1].Line != 3 {
+		t.Errorf("Expected the findings having the declaration or the statement changed, got %+v", filtered)
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"sort"
+
+	"github.com/esimonov/ifshort/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// report is the result of the analysis: the paths of the analyzed packages and the findings in them.
+type report struct {
+	Packages []string
+	Findings []finding
+}
+
+// finding is a diagnostic of the analyzer, resolved to the file positions.
+// Its JSON encoding is the stable output schema of the "json" format.
+type finding struct {
+	Package   string `json:"package"`
+	Rule      string `json:"rule"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	Message   string `json:"message"`
+	// Variable is the name of the variable that is only used in the statement, set for the "short-syntax" rule.
+	Variable string `json:"variable,omitempty"`
+	// Statement is the position of the if- or switch-statement the variable is used in, set for the "short-syntax" rule.
+	Statement *position `json:"statement,omitempty"`
+	Fixes     []fix     `json:"fixes,omitempty"`
+
+	// text is the plain text representation of the position, as printed by the "text" format.
+	text string
+	// function is the name of the function the finding is in, if any.
+	function string
+	// fingerprint identifies the finding in the baseline, regardless of its position.
+	fingerprint string
+}
+
+type position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	// Offset is the byte offset in the file, starting at 0.
+	Offset int `json:"offset"`
+}
+
+type fix struct {
+	Message string `json:"message"`
+	Edits   []edit `json:"edits"`
+}
+
+type edit struct {
+	Start   position `json:"start"`
+	End     position `json:"end"`
+	NewText string   `json:"new_text"`
+}
+
+// sourceCache holds the contents of the files, read on the first access.
+type sourceCache map[string][]byte
+
+func (sc sourceCache) get(name string) ([]byte, error) {
+	if src, ok := sc[name]; ok {
+		return src, nil
+	}
+
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	sc[name] = src
+	return src, nil
+}
+
+func newFinding(pkg *packages.Package, diag analysis.Diagnostic, sources sourceCache) (finding, error) {
+	start, end := newPosition(pkg.Fset, diag.Pos), newPosition(pkg.Fset, diag.Pos)
+	if diag.End.IsValid() {
+		end = newPosition(pkg.Fset, diag.End)
+	}
+
+	f := finding{
+		Package:   pkg.PkgPath,
+		Rule:      diag.Category,
+		File:      start.File,
+		Line:      start.Line,
+		Column:    start.Column,
+		EndLine:   end.Line,
+		EndColumn: end.Column,
+		Message:   diag.Message,
+		text:      pkg.Fset.Position(diag.Pos).String(),
+	}
+
+	src, err := sources.get(start.File)
+	if err != nil {
+		return finding{}, err
+	}
+	if end.Offset > len(src) {
+		return finding{}, fmt.Errorf("%s: file has changed during analysis", f.text)
+	}
+
+	if diag.Category == analyzer.CategoryShortSyntax {
+		f.Variable = string(src[start.Offset:end.Offset])
+
+		for _, rel := range diag.Related {
+			stmt := newPosition(pkg.Fset, rel.Pos)
+			f.Statement = &stmt
+		}
+	}
+
+	for _, sf := range diag.SuggestedFixes {
+		fx := fix{Message: sf.Message}
+
+		for _, te := range sf.TextEdits {
+			fx.Edits = append(fx.Edits, edit{
+				Start:   newPosition(pkg.Fset, te.Pos),
+				End:     newPosition(pkg.Fset, te.End),
+				NewText: string(te.NewText),
+			})
+		}
+		f.Fixes = append(f.Fixes, fx)
+	}
+
+	// The fingerprint of a short-syntax finding is based on the whole declaration rather than the variable name only.
+	node, function := getEnclosingNodes(pkg, diag)
+	text := src[start.Offset:end.Offset]
+	if node != nil {
+		text = src[pkg.Fset.Position(node.Pos()).Offset:pkg.Fset.Position(node.End()).Offset]
+	}
+
+	f.function = function
+	f.fingerprint = getFingerprint(f, string(text))
+	return f, nil
+}
+
+func newPosition(fset *token.FileSet, pos token.Pos) position {
+	p := fset.Position(pos)
+	return position{File: p.Filename, Line: p.Line, Column: p.Column, Offset: p.Offset}
+}
+
+// dedupFindings sorts the findings by position and removes the duplicates,
+// which are reported for the files belonging to several packages, e.g. to a package and its test variant.
+func dedupFindings(findings []finding) []finding {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	type key struct {
+		file         string
+		line, column int
+		message      string
+	}
+	seen := map[key]bool{}
+
+	deduped := findings[:0]
+	for _, f := range findings {
+		k := key{f.File, f.Line, f.Column, f.Message}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		deduped = append(deduped, f)
+	}
+	return deduped
+}
+
+// dedupStrings sorts the strings and removes the duplicates.
+func dedupStrings(ss []string) []string {
+	sort.Strings(ss)
+
+	deduped := ss[:0]
+	for i, s := range ss {
+		if i == 0 || s != ss[i-1] {
+			deduped = append(deduped, s)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// applyFixes applies the first suggested fix of every finding to the files.
+// The fixes whose edits overlap with the already applied ones are skipped.
+func applyFixes(findings []finding) error {
+	edits := map[string][]edit{}
+
+	for _, f := range findings {
+		if len(f.Fixes) == 0 {
+			continue
+		}
+
+		fx := f.Fixes[0]
+		if overlaps(edits, fx.Edits) {
+			continue
+		}
+		for _, e := range fx.Edits {
+			edits[e.Start.File] = append(edits[e.Start.File], e)
+		}
+	}
+
+	for file, fileEdits := range edits {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		fixed, err := applyEdits(src, fileEdits)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, fixed, info.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func overlaps(edits map[string][]edit, candidates []edit) bool {
+	for _, c := range candidates {
+		for _, e := range edits[c.Start.File] {
+			if c.Start.Offset < e.End.Offset && e.Start.Offset < c.End.Offset {
+				return true
+			}
+			// Two insertions at the same offset would be applied in an unspecified order.
+			if c.Start.Offset == e.Start.Offset {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyEdits returns a copy of src with the edits applied. The edits must not overlap.
+func applyEdits(src []byte, edits []edit) ([]byte, error) {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Start.Offset < edits[j].Start.Offset })
+
+	var out []byte
+	last := 0
+
+	for _, e := range edits {
+		if e.Start.Offset < last || e.End.Offset < e.Start.Offset || e.End.Offset > len(src) {
+			return nil, fmt.Errorf("invalid edit at %d:%d", e.Start.Line, e.Start.Column)
+		}
+
+		out = append(out, src[last:e.Start.Offset]...)
+		out = append(out, e.NewText...)
+		last = e.End.Offset
+	}
+	return append(out, src[last:]...), nil
+}
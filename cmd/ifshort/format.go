@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// writeText writes the findings in the same plain text format as the standard analysis drivers.
+func writeText(w io.Writer, r *report) error {
+	for _, f := range r.Findings {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.text, f.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSON writes the findings as a JSON object, with the findings listed under the "findings" key.
+func writeJSON(w io.Writer, r *report) error {
+	findings := r.Findings
+	if findings == nil {
+		findings = []finding{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+
+	return enc.Encode(struct {
+		Findings []finding `json:"findings"`
+	}{findings})
+}
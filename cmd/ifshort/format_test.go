@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func getTestFinding(wd string) finding {
+	file := filepath.Join(wd, "p.go")
+
+	return finding{
+		Package:   "p",
+		Rule:      "short-syntax",
+		File:      file,
+		Line:      4,
+		Column:    2,
+		EndLine:   4,
+		EndColumn: 3,
+		Message:   "variable 'v' is only used in the if-statement (p.go:5:2); consider using short syntax",
+		Variable:  "v",
+		Statement: &position{File: file, Line: 5, Column: 2, Offset: 33},
+		Fixes: []fix{{
+			Message: "Move 'v := f()' into the if-statement",
+			Edits: []edit{
+				{Start: position{File: file, Line: 4, Column: 2, Offset: 23}, End: position{File: file, Line: 5, Column: 2, Offset: 33}},
+				{Start: position{File: file, Line: 5, Column: 5, Offset: 36}, End: position{File: file, Line: 5, Column: 5, Offset: 36}, NewText: "v := f(); "},
+			},
+		}},
+		text: "p.go:4:2",
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeText(&buf, &report{Findings: []finding{getTestFinding("")}}); err != nil {
+		t.Fatalf("Failed to write: %s", err)
+	}
+
+	expected := "p.go:4:2: variable 'v' is only used in the if-statement (p.go:5:2); consider using short syntax\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, &report{Findings: []finding{getTestFinding("")}}); err != nil {
+		t.Fatalf("Failed to write: %s", err)
+	}
+
+	var out struct {
+		Findings []map[string]interface{} `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+
+	if len(out.Findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d", len(out.Findings))
+	}
+
+	for _, key := range []string{"package", "rule", "file", "line", "column", "end_line", "end_column", "message", "variable", "statement", "fixes"} {
+		if _, ok := out.Findings[0][key]; !ok {
+			t.Errorf("Expected key %q in %v", key, out.Findings[0])
+		}
+	}
+}
+
+func TestWriteJSON_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, &report{}); err != nil {
+		t.Fatalf("Failed to write: %s", err)
+	}
+
+	expected := "{\n\t\"findings\": []\n}\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get wd: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, &report{Findings: []finding{getTestFinding(wd)}}); err != nil {
+		t.Fatalf("Failed to write: %s", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+
+	if log.Version != sarifVersion || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("Expected a single run with a single result, got %s", buf.String())
+	}
+
+	result := log.Runs[0].Results[0]
+	if result.RuleID != "short-syntax" || log.Runs[0].Tool.Driver.Rules[result.RuleIndex].ID != result.RuleID {
+		t.Errorf("Expected the result to refer to short-syntax rule, got %s at %d", result.RuleID, result.RuleIndex)
+	}
+
+	loc := result.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "p.go" || loc.ArtifactLocation.URIBaseID != sarifSrcRoot {
+		t.Errorf("Expected the URI relative to %s, got %+v", sarifSrcRoot, loc.ArtifactLocation)
+	}
+	if loc.Region.StartLine != 4 || loc.Region.StartColumn != 2 {
+		t.Errorf("Expected the region to start at 4:2, got %+v", loc.Region)
+	}
+
+	if len(result.RelatedLocations) != 1 || result.RelatedLocations[0].PhysicalLocation.Region.StartLine != 5 {
+		t.Errorf("Expected the if-statement at line 5 as related location, got %+v", result.RelatedLocations)
+	}
+
+	if len(result.Fixes) != 1 || len(result.Fixes[0].ArtifactChanges) != 1 || len(result.Fixes[0].ArtifactChanges[0].Replacements) != 2 {
+		t.Errorf("Expected a single fix with 2 replacements in a single file, got %+v", result.Fixes)
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	src := []byte("package p\n\nfunc g() {\n\tv := f()\n\tif v != nil {\n\t}\n}\n")
+
+	fixed, err := applyEdits(src, getTestFinding("").Fixes[0].Edits)
+	if err != nil {
+		t.Fatalf("Failed to apply edits: %s", err)
+	}
+
+	expected := "package p\n\nfunc g() {\n\tif v := f(); v != nil {\n\t}\n}\n"
+	if string(fixed) != expected {
+		t.Errorf("Expected %q, got %q", expected, fixed)
+	}
+}
+
+func TestWriteCheckstyle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCheckstyle(&buf, &report{Findings: []finding{getTestFinding("")}}); err != nil {
+		t.Fatalf("Failed to write: %s", err)
+	}
+
+	var cs checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &cs); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+
+	if len(cs.Files) != 1 || cs.Files[0].Name != "p.go" || len(cs.Files[0].Errors) != 1 {
+		t.Fatalf("Expected a single file with a single error, got %s", buf.String())
+	}
+
+	e := cs.Files[0].Errors[0]
+	if e.Line != 4 || e.Column != 2 || e.Source != "ifshort.short-syntax" || e.Message != getTestFinding("").Message {
+		t.Errorf("Unexpected error %+v", e)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJUnit(&buf, &report{Packages: []string{"o", "p"}, Findings: []finding{getTestFinding("")}}); err != nil {
+		t.Fatalf("Failed to write: %s", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+
+	if len(suites.TestSuites) != 1 {
+		t.Fatalf("Expected a single test suite, got %s", buf.String())
+	}
+
+	suite := suites.TestSuites[0]
+	if suite.Tests != 2 || suite.Failures != 1 {
+		t.Errorf("Expected 2 tests with 1 failure, got %d with %d", suite.Tests, suite.Failures)
+	}
+
+	if len(suite.TestCases[0].Failures) != 0 || len(suite.TestCases[1].Failures) != 1 {
+		t.Errorf("Expected only the test case of p to fail, got %+v", suite.TestCases)
+	}
+}
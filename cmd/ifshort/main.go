@@ -1,10 +1,284 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/esimonov/ifshort/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
 	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	formatUsage = `output format, one of "text", "json", "sarif", "checkstyle" or "junit".`
+	fixUsage    = `apply the suggested fixes.`
+	testUsage   = `also analyze the test files.`
+
+	baselineUsage      = `path of the baseline file; the findings recorded in it aren't reported.`
+	writeBaselineUsage = `path of the baseline file to record the findings to, instead of reporting them.`
+	diffUsage          = `path of a unified diff, e.g. the output of git diff, or "-" to read it from stdin; only the findings on the added or modified lines are reported.`
 )
 
+// writers are the functions writing the findings in the supported output formats.
+var writers = map[string]func(w io.Writer, r *report) error{
+	"text":       writeText,
+	"json":       writeJSON,
+	"sarif":      writeSARIF,
+	"checkstyle": writeCheckstyle,
+	"junit":      writeJUnit,
+}
+
+// driverFlags are the flags only the reporting driver of this command supports.
+// Without them, the command is the standard single analyzer checker, which also serves go vet -vettool.
+var driverFlags = map[string]bool{"baseline": true, "write-baseline": true, "diff": true}
+
 func main() {
-	singlechecker.Main(analyzer.Analyzer)
+	log.SetFlags(0)
+	log.SetPrefix(analyzer.Analyzer.Name + ": ")
+
+	if !needsDriver(os.Args[1:]) {
+		a, err := newWorkingDirAnalyzer()
+		if err != nil {
+			log.Fatal(err)
+		}
+		// The text format is the standard output of the checker, so the flag is only accepted here.
+		flag.String("format", "text", formatUsage)
+		singlechecker.Main(a)
+		return
+	}
+
+	analyzer.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		flag.Var(f.Value, f.Name, f.Usage)
+	})
+
+	format := flag.String("format", "text", formatUsage)
+	fix := flag.Bool("fix", false, fixUsage)
+	tests := flag.Bool("test", true, testUsage)
+	baselineFile := flag.String("baseline", "", baselineUsage)
+	writeBaselineFile := flag.String("write-baseline", "", writeBaselineUsage)
+	diffFile := flag.String("diff", "", diffUsage)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "%s\n\nusage: ifshort [flags] [packages]\n\nflags:\n", analyzer.Analyzer.Doc)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	write, ok := writers[*format]
+	if !ok {
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	// The flags set on the command line take precedence over the configuration files.
+	flags := map[string]string{}
+	flag.Visit(func(f *flag.Flag) {
+		if analyzer.Analyzer.Flags.Lookup(f.Name) != nil {
+			flags[f.Name] = f.Value.String()
+		}
+	})
+
+	r, err := analyze(flag.Args(), *tests, flags)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *writeBaselineFile != "" {
+		if err := writeBaseline(*writeBaselineFile, r.Findings); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *baselineFile != "" {
+		known, err := readBaseline(*baselineFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.Findings = filterBaseline(r.Findings, known)
+	}
+
+	if *diffFile != "" {
+		cl, err := readDiff(*diffFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.Findings = filterDiff(r.Findings, cl)
+	}
+
+	if err := write(os.Stdout, r); err != nil {
+		log.Fatal(err)
+	}
+
+	if *fix {
+		if err := applyFixes(r.Findings); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(r.Findings) != 0 {
+		os.Exit(3)
+	}
+}
+
+// needsDriver checks if the arguments ask for any of the output formats other than text, or for the driver flags.
+func needsDriver(args []string) bool {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if driverFlags[name] {
+			return true
+		}
+		if name == "format" {
+			if !hasValue && i+1 < len(args) {
+				value = args[i+1]
+			}
+			if value != "text" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newWorkingDirAnalyzer returns the analyzer configured with the configuration file found for the working directory,
+// which applies to all the packages when the command runs as the standard checker.
+func newWorkingDirAnalyzer() (*analysis.Analyzer, error) {
+	cfg := analyzer.DefaultConfig()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	cf, err := configLoader{}.find(wd)
+	if err != nil {
+		return nil, err
+	}
+	if cf != nil {
+		if err := cf.apply(&cfg, wd); err != nil {
+			return nil, err
+		}
+	}
+	return analyzer.NewAnalyzer(cfg), nil
+}
+
+// analyze loads the packages matching the patterns and returns the report of the analyzer.
+// The flags are the analyzer flags set on the command line, by name.
+func analyze(patterns []string, tests bool, flags map[string]string) (*report, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: tests}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("failed to load packages")
+	}
+
+	groups, err := groupPackages(pkgs, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []finding
+	var pkgPaths []string
+	sources := sourceCache{}
+
+	for _, g := range groups {
+		graph, err := checker.Analyze([]*analysis.Analyzer{g.analyzer}, g.pkgs, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, act := range graph.Roots {
+			if act.Err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err)
+			}
+
+			// The test main packages are generated, so they are left out of the report.
+			if strings.HasSuffix(act.Package.ID, ".test") {
+				continue
+			}
+			pkgPaths = append(pkgPaths, act.Package.PkgPath)
+
+			for _, diag := range act.Diagnostics {
+				f, err := newFinding(act.Package, diag, sources)
+				if err != nil {
+					return nil, err
+				}
+				findings = append(findings, f)
+			}
+		}
+	}
+	return &report{
+		Packages: dedupStrings(pkgPaths),
+		Findings: dedupFindings(findings),
+	}, nil
+}
+
+// packageGroup is a set of packages analyzed with the same configuration.
+type packageGroup struct {
+	analyzer *analysis.Analyzer
+	pkgs     []*packages.Package
+}
+
+// groupPackages groups the packages by their configuration: the default one, updated with the settings
+// of the configuration file found for the directory of the package, and then with the flags.
+func groupPackages(pkgs []*packages.Package, flags map[string]string) ([]*packageGroup, error) {
+	loader := configLoader{}
+	byConfig := map[analyzer.Config]*packageGroup{}
+
+	var groups []*packageGroup
+
+	for _, pkg := range pkgs {
+		cfg := analyzer.DefaultConfig()
+
+		if dir := getPackageDir(pkg); dir != "" {
+			cf, err := loader.find(dir)
+			if err != nil {
+				return nil, err
+			}
+			if cf != nil {
+				if err := cf.apply(&cfg, dir); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		g, ok := byConfig[cfg]
+		if !ok {
+			g = &packageGroup{analyzer: analyzer.NewAnalyzer(cfg)}
+			for name, value := range flags {
+				if err := g.analyzer.Flags.Set(name, value); err != nil {
+					return nil, err
+				}
+			}
+
+			byConfig[cfg] = g
+			groups = append(groups, g)
+		}
+		g.pkgs = append(g.pkgs, pkg)
+	}
+	return groups, nil
+}
+
+func getPackageDir(pkg *packages.Package) string {
+	if pkg.Dir != "" {
+		return pkg.Dir
+	}
+	if len(pkg.GoFiles) != 0 {
+		return filepath.Dir(pkg.GoFiles[0])
+	}
+	return ""
 }
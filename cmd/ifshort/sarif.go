@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/esimonov/ifshort/pkg/analyzer"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifSrcRoot is the base of the relative URIs of the files, which is the working directory.
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+// sarifRules are the rules reported by the analyzer, one per diagnostic category.
+var sarifRules = []sarifRule{
+	{
+		ID:               analyzer.CategoryShortSyntax,
+		ShortDescription: sarifMessage{Text: "Variable is only used in the if-statement; consider using short syntax."},
+	},
+	{
+		ID:               analyzer.CategoryLongInit,
+		ShortDescription: sarifMessage{Text: "Declaration is too long for the init clause of if-statement."},
+	},
+	{
+		ID:               analyzer.CategoryUnusedDirective,
+		ShortDescription: sarifMessage{Text: "Directive doesn't suppress any report."},
+	},
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId"`
+	RuleIndex        int             `json:"ruleIndex"`
+	Level            string          `json:"level"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+	Fixes            []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                  `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// writeSARIF writes the findings as a SARIF 2.1.0 log with a single run.
+func writeSARIF(w io.Writer, r *report) error {
+	wd, _ := os.Getwd()
+
+	results := make([]sarifResult, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		results = append(results, newSARIFResult(wd, f))
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           analyzer.Analyzer.Name,
+				InformationURI: "https://github.com/esimonov/ifshort",
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(log)
+}
+
+func newSARIFResult(wd string, f finding) sarifResult {
+	result := sarifResult{
+		RuleID:  f.Rule,
+		Level:   "warning",
+		Message: sarifMessage{Text: f.Message},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: newSARIFArtifactLocation(wd, f.File),
+				Region: sarifRegion{
+					StartLine:   f.Line,
+					StartColumn: f.Column,
+					EndLine:     f.EndLine,
+					EndColumn:   f.EndColumn,
+				},
+			},
+		}},
+	}
+
+	for i, rule := range sarifRules {
+		if rule.ID == f.Rule {
+			result.RuleIndex = i
+		}
+	}
+
+	if f.Statement != nil {
+		id := 0
+		result.RelatedLocations = []sarifLocation{{
+			ID: &id,
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: newSARIFArtifactLocation(wd, f.Statement.File),
+				Region:           sarifRegion{StartLine: f.Statement.Line, StartColumn: f.Statement.Column},
+			},
+			Message: &sarifMessage{Text: "statement the variable is used in"},
+		}}
+	}
+
+	for _, fx := range f.Fixes {
+		changes := map[string]*sarifArtifactChange{}
+		var files []string
+
+		for _, e := range fx.Edits {
+			change, ok := changes[e.Start.File]
+			if !ok {
+				change = &sarifArtifactChange{ArtifactLocation: newSARIFArtifactLocation(wd, e.Start.File)}
+				changes[e.Start.File] = change
+				files = append(files, e.Start.File)
+			}
+
+			replacement := sarifReplacement{DeletedRegion: sarifRegion{
+				StartLine:   e.Start.Line,
+				StartColumn: e.Start.Column,
+				EndLine:     e.End.Line,
+				EndColumn:   e.End.Column,
+			}}
+			if e.NewText != "" {
+				replacement.InsertedContent = &sarifMessage{Text: e.NewText}
+			}
+			change.Replacements = append(change.Replacements, replacement)
+		}
+
+		sf := sarifFix{Description: sarifMessage{Text: fx.Message}}
+		for _, file := range files {
+			sf.ArtifactChanges = append(sf.ArtifactChanges, *changes[file])
+		}
+		result.Fixes = append(result.Fixes, sf)
+	}
+	return result
+}
+
+// newSARIFArtifactLocation returns the location of the file relative to the working directory if it's inside of it,
+// and its absolute file URI otherwise.
+func newSARIFArtifactLocation(wd, file string) sarifArtifactLocation {
+	if rel, err := filepath.Rel(wd, file); err == nil && wd != "" && !strings.HasPrefix(rel, "..") {
+		return sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: sarifSrcRoot}
+	}
+	return sarifArtifactLocation{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String()}
+}
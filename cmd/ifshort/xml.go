@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/esimonov/ifshort/pkg/analyzer"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// writeCheckstyle writes the findings as a Checkstyle XML report, with an element per file having findings.
+func writeCheckstyle(w io.Writer, r *report) error {
+	cs := checkstyleReport{Version: "5.0"}
+
+	for _, f := range r.Findings {
+		if len(cs.Files) == 0 || cs.Files[len(cs.Files)-1].Name != f.File {
+			cs.Files = append(cs.Files, checkstyleFile{Name: f.File})
+		}
+
+		file := &cs.Files[len(cs.Files)-1]
+		file.Errors = append(file.Errors, checkstyleError{
+			Line:     f.Line,
+			Column:   f.Column,
+			Severity: "warning",
+			Message:  f.Message,
+			Source:   analyzer.Analyzer.Name + "." + f.Rule,
+		})
+	}
+	return writeXML(w, cs)
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes the findings as a JUnit XML report, with a test case per analyzed package
+// and a failure per finding in it.
+func writeJUnit(w io.Writer, r *report) error {
+	suite := junitTestSuite{Name: analyzer.Analyzer.Name}
+	cases := map[string]int{}
+
+	for _, pkg := range r.Packages {
+		cases[pkg] = len(suite.TestCases)
+		suite.TestCases = append(suite.TestCases, junitTestCase{Name: pkg, ClassName: pkg})
+	}
+
+	for _, f := range r.Findings {
+		i, ok := cases[f.Package]
+		if !ok {
+			i = len(suite.TestCases)
+			cases[f.Package] = i
+			suite.TestCases = append(suite.TestCases, junitTestCase{Name: f.Package, ClassName: f.Package})
+		}
+
+		suite.TestCases[i].Failures = append(suite.TestCases[i].Failures, junitFailure{
+			Message: f.Message,
+			Type:    f.Rule,
+			Text:    fmt.Sprintf("%s: %s", f.text, f.Message),
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	for _, tc := range suite.TestCases {
+		if len(tc.Failures) != 0 {
+			suite.Failures++
+		}
+	}
+	return writeXML(w, junitTestSuites{TestSuites: []junitTestSuite{suite}})
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
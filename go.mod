@@ -1,10 +1,15 @@
 module github.com/esimonov/ifshort
 
-go 1.17
+go 1.22.0
 
-require golang.org/x/tools v0.0.0-20201028025901-8cd080b735b3
+require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/golangci/plugin-module-register v0.1.1
+	golang.org/x/tools v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	golang.org/x/mod v0.3.0 // indirect
-	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 )
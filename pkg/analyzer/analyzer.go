@@ -1,274 +1,478 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
-var maxDeclChars, maxDeclLines int
-
 const (
 	maxDeclLinesUsage = `maximum length of variable declaration measured in number of lines, after which the linter won't suggest using short syntax.
 Has precedence over max-decl-chars.`
-	maxDeclCharsUsage = `maximum length of variable declaration measured in number of characters, after which the linter won't suggest using short syntax.`
+	maxDeclCharsUsage   = `maximum length of variable declaration measured in number of characters, after which the linter won't suggest using short syntax.`
+	checkSwitchUsage    = `also suggest short syntax for switch-statements, if a variable is only used in the tag of switch-statement or in the type switch guard.`
+	checkLongInitUsage  = `report declarations in the init clause of if-statement that exceed max-decl-lines or max-decl-chars, and thus should be declared before the if-statement.`
+	reportUnusedUsage   = `report //nolint:ifshort and //ifshort:ignore directives that don't suppress anything.`
+	includeGenUsage     = `analyze the files having the standard "Code generated ... DO NOT EDIT." comment, which are skipped by default.`
+	excludePathsUsage   = `regular expression matching paths of the files that shouldn't be analyzed, e.g. "_mock\.go$|/internal/gen/".`
+	verifyFixesUsage    = `don't report the variables whose suggested fix wouldn't type-check, which is verified by type-checking the rewritten package.`
+	varsUsage           = `comma-separated list of names, e.g. "err,ok", or regular expression matching names of the variables to suggest short syntax for, e.g. "^(err|ok)$".`
+	idiomaticUsage      = `only suggest short syntax for the variables implementing error, and for the boolean results of comma-ok expressions.`
+	excludeTypesUsage   = `comma-separated list of fully qualified types of the variables not to suggest short syntax for, e.g. "context.CancelFunc,*sync.Mutex".`
+	excludeCalleesUsage = `comma-separated list of fully qualified functions and methods, the variables declared with whose results short syntax isn't suggested for, e.g. "go.opentelemetry.io/otel/trace.Tracer.Start".`
+	declLengthModeUsage = `how to measure the length of declarations and lines: "bytes", "runes", or "columns", which are runes with tabs expanded to tab-width.`
+	tabWidthUsage       = `width of tab in columns, used if decl-length-mode is "columns".`
+	maxLineLengthUsage  = `maximum length of the first line of if-statement with the declaration moved into it, after which the linter won't suggest using short syntax. 0 means no limit.`
+	maxIfLineCharsUsage = `maximum length of the header of if-statement with the declaration moved into it, e.g. "if v := getValue(); v != nil {", after which the linter won't suggest using short syntax. 0 means no limit.`
 )
 
-func init() {
-	Analyzer.Flags.IntVar(&maxDeclLines, "max-decl-lines", 1, maxDeclLinesUsage)
-	Analyzer.Flags.IntVar(&maxDeclChars, "max-decl-chars", 30, maxDeclCharsUsage)
-}
+// Categories of the diagnostics reported by ifshort linter.
+const (
+	// CategoryShortSyntax is the category of the variables that are only used in an if- or switch-statement.
+	CategoryShortSyntax = "short-syntax"
+	// CategoryLongInit is the category of the init clauses that are too long, reported if CheckLongInit is set.
+	CategoryLongInit = "long-init"
+	// CategoryUnusedDirective is the category of the unused directives, reported if ReportUnusedDirectives is set.
+	CategoryUnusedDirective = "unused-directive"
+)
+
+// Analyzer is an analysis.Analyzer instance for ifshort linter, created with DefaultConfig.
+var Analyzer = NewAnalyzer(DefaultConfig())
+
+// NewAnalyzer returns a new analysis.Analyzer instance for ifshort linter.
+// Its flags are bound to its own copy of cfg, so that differently configured instances don't affect each other.
+func NewAnalyzer(cfg Config) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name:     "ifshort",
+		Doc:      "Checks that your code uses short syntax for if-statements whenever possible.",
+		Run:      cfg.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	a.Flags.IntVar(&cfg.MaxDeclLines, "max-decl-lines", cfg.MaxDeclLines, maxDeclLinesUsage)
+	a.Flags.IntVar(&cfg.MaxDeclChars, "max-decl-chars", cfg.MaxDeclChars, maxDeclCharsUsage)
+	a.Flags.BoolVar(&cfg.CheckSwitch, "check-switch", cfg.CheckSwitch, checkSwitchUsage)
+	a.Flags.BoolVar(&cfg.CheckLongInit, "check-long-init", cfg.CheckLongInit, checkLongInitUsage)
+	a.Flags.BoolVar(&cfg.ReportUnusedDirectives, "report-unused-directives", cfg.ReportUnusedDirectives, reportUnusedUsage)
+	a.Flags.BoolVar(&cfg.IncludeGenerated, "include-generated", cfg.IncludeGenerated, includeGenUsage)
+	a.Flags.StringVar(&cfg.ExcludePaths, "exclude-paths", cfg.ExcludePaths, excludePathsUsage)
+	a.Flags.BoolVar(&cfg.VerifyFixes, "verify-fixes", cfg.VerifyFixes, verifyFixesUsage)
+	a.Flags.StringVar(&cfg.Vars, "vars", cfg.Vars, varsUsage)
+	a.Flags.BoolVar(&cfg.IdiomaticOnly, "idiomatic-only", cfg.IdiomaticOnly, idiomaticUsage)
+	a.Flags.StringVar(&cfg.ExcludeTypes, "exclude-types", cfg.ExcludeTypes, excludeTypesUsage)
+	a.Flags.StringVar(&cfg.ExcludeCallees, "exclude-callees", cfg.ExcludeCallees, excludeCalleesUsage)
+	a.Flags.StringVar(&cfg.DeclLengthMode, "decl-length-mode", cfg.DeclLengthMode, declLengthModeUsage)
+	a.Flags.IntVar(&cfg.TabWidth, "tab-width", cfg.TabWidth, tabWidthUsage)
+	a.Flags.IntVar(&cfg.MaxLineLength, "max-line-length", cfg.MaxLineLength, maxLineLengthUsage)
+	a.Flags.IntVar(&cfg.MaxIfLineChars, "max-if-line-chars", cfg.MaxIfLineChars, maxIfLineCharsUsage)
 
-// Analyzer is an analysis.Analyzer instance for ifshort linter.
-var Analyzer = &analysis.Analyzer{
-	Name:     "ifshort",
-	Doc:      "Checks that your code uses short syntax for if-statements whenever possible.",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	return a
 }
 
-func run(pass *analysis.Pass) (interface{}, error) {
+func (cfg *Config) run(pass *analysis.Pass) (interface{}, error) {
+	skipped, err := cfg.getSkippedFiles(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := cfg.getVarFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	meter, err := cfg.getLengthMeter(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, file := range pass.Files {
+		if !skipped[pass.Fset.File(file.Package)] {
+			files = append(files, file)
+		}
+	}
+
 	inspector := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	r := &reporter{
+		pass:       pass,
+		directives: getDirectives(pass, files),
+	}
+
 	nodeFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
 	}
 
 	inspector.Preorder(nodeFilter, func(node ast.Node) {
-		fdecl := node.(*ast.FuncDecl)
-
-		/*if fdecl.Name.Name != "notUsed_BinaryExpressionInIndex_OK" {
+		if skipped[pass.Fset.File(node.Pos())] {
 			return
-		}*/
+		}
+
+		var body *ast.BlockStmt
 
-		if fdecl == nil || fdecl.Body == nil {
+		// Function literals are analyzed on their own, the references to the captured variables
+		// are accounted for when walking through the body of the enclosing function.
+		switch v := node.(type) {
+		case *ast.FuncDecl:
+			body = v.Body
+		case *ast.FuncLit:
+			body = v.Body
+		}
+
+		if body == nil {
 			return
 		}
 
-		candidates := getNamedOccurrenceMap(fdecl, pass)
+		candidates := getObjectOccurrenceMap(body, pass, cfg, filter, meter)
 
-		for _, stmt := range fdecl.Body.List {
-			candidates.checkStatement(stmt, token.NoPos)
+		for _, stmt := range body.List {
+			candidates.checkStatement(pass, stmt, token.NoPos)
 		}
 
-		for varName := range candidates {
-			for marker, occ := range candidates[varName] {
+		for obj := range candidates {
+			for marker, occ := range candidates[obj] {
 				//  If two or more vars with the same scope marker - skip them.
 				if candidates.isFoundByScopeMarker(marker) {
 					continue
 				}
 
-				pass.Reportf(occ.declarationPos,
-					"variable '%s' is only used in the if-statement (%s); consider using short syntax",
-					varName, pass.Fset.Position(occ.ifStmtPos))
+				headerLength := 0
+				if cfg.MaxIfLineChars > 0 {
+					if headerLength = getHeaderLength(pass, meter, occ); headerLength > cfg.MaxIfLineChars {
+						continue
+					}
+				}
+
+				if cfg.MaxLineLength > 0 && meter.getLineLengthWithInit(occ) > cfg.MaxLineLength {
+					continue
+				}
+
+				fixes := getSuggestedFixes(pass, occ)
+				if cfg.VerifyFixes && len(fixes) != 0 && verifyFix(pass, fixes[0]) != nil {
+					continue
+				}
+
+				message := fmt.Sprintf("variable '%s' is only used in the %s-statement (%s); consider using short syntax",
+					obj.Name(), occ.stmtTok, pass.Fset.Position(occ.ifStmtPos))
+				if headerLength != 0 && float64(headerLength) >= closeToMaxIfLineChars*float64(cfg.MaxIfLineChars) {
+					message += fmt.Sprintf(" (the %s-line would be %d characters long, max-if-line-chars is %d)",
+						occ.stmtTok, headerLength, cfg.MaxIfLineChars)
+				}
+
+				r.report(analysis.Diagnostic{
+					Pos:            occ.declarationPos,
+					End:            occ.declarationPos + token.Pos(len(obj.Name())),
+					Category:       CategoryShortSyntax,
+					Message:        message,
+					SuggestedFixes: fixes,
+					Related: []analysis.RelatedInformation{{
+						Pos:     occ.ifStmtPos,
+						Message: fmt.Sprintf("%s-statement", occ.stmtTok),
+					}},
+				}, occ.ifStmtPos)
 			}
 		}
 	})
+
+	if cfg.CheckLongInit {
+		cfg.checkLongInits(r, meter, inspector, skipped)
+	}
+	if cfg.ReportUnusedDirectives {
+		r.reportUnusedDirectives()
+	}
 	return nil, nil
 }
 
-func (nom namedOccurrenceMap) checkStatement(stmt ast.Stmt, ifPos token.Pos) {
+// checkLongInits reports the declarations in the init clauses of if-statements that exceed the flag settings.
+// The else-if statements are skipped, as their init clauses can't be simply moved before them.
+func (cfg *Config) checkLongInits(r *reporter, meter *lengthMeter, inspector *inspector.Inspector, skipped map[*token.File]bool) {
+	elseIfs := map[*ast.IfStmt]bool{}
+
+	inspector.Preorder([]ast.Node{(*ast.IfStmt)(nil)}, func(node ast.Node) {
+		ifStmt := node.(*ast.IfStmt)
+
+		if elseIf, ok := ifStmt.Else.(*ast.IfStmt); ok {
+			elseIfs[elseIf] = true
+		}
+
+		init, ok := ifStmt.Init.(*ast.AssignStmt)
+		if !ok || init.Tok != token.DEFINE || elseIfs[ifStmt] || skipped[r.pass.Fset.File(ifStmt.Pos())] {
+			return
+		}
+
+		// The declarations are measured the same way as for short syntax, so that the checks never contradict each other:
+		// the declaration is too long if short syntax wouldn't be suggested for any of its variables.
+		for i, el := range init.Lhs {
+			if ident, ok := el.(*ast.Ident); ok && ident.Name != "_" && cfg.areFlagSettingsSatisfied(r.pass, meter, init, i) {
+				return
+			}
+		}
+
+		names := make([]string, 0, len(init.Lhs))
+		for _, el := range init.Lhs {
+			if ident, ok := el.(*ast.Ident); ok {
+				names = append(names, ident.Name)
+			}
+		}
+
+		r.report(analysis.Diagnostic{
+			Pos:      init.Pos(),
+			End:      init.End(),
+			Category: CategoryLongInit,
+			Message: fmt.Sprintf("declaration of '%s' is too long for the init clause of if-statement; consider declaring it before the if-statement",
+				strings.Join(names, ", ")),
+		}, ifStmt.If)
+	})
+}
+
+// checkStatement removes the occurrences of variables that are referenced in the statement
+// outside of the if-statement at ifPos. Every kind of ast.Stmt is walked through completely.
+func (oom objectOccurrenceMap) checkStatement(pass *analysis.Pass, stmt ast.Stmt, ifPos token.Pos) {
 	switch v := stmt.(type) {
 	case *ast.AssignStmt:
 		for _, el := range v.Rhs {
-			nom.checkExpression(el, ifPos)
+			oom.checkExpression(pass, el, ifPos)
 		}
 		if isAssign(v.Tok) {
 			for _, el := range v.Lhs {
-				nom.checkExpression(el, ifPos)
+				oom.checkExpression(pass, el, ifPos)
+			}
+		} else if v.Tok == token.DEFINE {
+			// Once the earlier declaration is moved, a redeclaration declares the variable anew,
+			// which doesn't compile unless the variable is used after it.
+			for _, el := range v.Lhs {
+				if ident, ok := el.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] != nil && !isUsedAfter(pass, ident) {
+					oom.checkExpression(pass, ident, ifPos)
+				}
 			}
 		}
-	case *ast.DeferStmt:
-		for _, a := range v.Call.Args {
-			nom.checkExpression(a, ifPos)
-		}
-	case *ast.ExprStmt:
-		switch v.X.(type) {
-		case *ast.CallExpr, *ast.UnaryExpr:
-			nom.checkExpression(v.X, ifPos)
+	case *ast.BlockStmt:
+		for _, el := range v.List {
+			oom.checkStatement(pass, el, ifPos)
 		}
-	case *ast.ForStmt:
-		for _, el := range v.Body.List {
-			nom.checkStatement(el, ifPos)
+	case *ast.CaseClause:
+		for _, el := range v.List {
+			oom.checkExpression(pass, el, ifPos)
 		}
-
-		if bexpr, ok := v.Cond.(*ast.BinaryExpr); ok {
-			nom.checkExpression(bexpr.X, ifPos)
-			nom.checkExpression(bexpr.Y, ifPos)
+		for _, el := range v.Body {
+			oom.checkStatement(pass, el, ifPos)
 		}
+	case *ast.CommClause:
+		oom.checkStatement(pass, v.Comm, ifPos)
 
-		nom.checkStatement(v.Post, ifPos)
-	case *ast.GoStmt:
-		for _, a := range v.Call.Args {
-			nom.checkExpression(a, ifPos)
-		}
-	case *ast.IfStmt:
-		for _, el := range v.Body.List {
-			nom.checkStatement(el, v.If)
+		for _, el := range v.Body {
+			oom.checkStatement(pass, el, ifPos)
 		}
-		if elseBlock, ok := v.Else.(*ast.BlockStmt); ok {
-			for _, el := range elseBlock.List {
-				nom.checkStatement(el, v.If)
-			}
-		}
-
-		switch cond := v.Cond.(type) {
-		case *ast.UnaryExpr:
-			nom.checkExpression(cond.X, v.If)
-		case *ast.BinaryExpr:
-			nom.checkExpression(cond.X, v.If)
-			nom.checkExpression(cond.Y, v.If)
-		case *ast.CallExpr:
-			nom.checkExpression(cond, v.If)
+	case *ast.DeclStmt:
+		genDecl, ok := v.Decl.(*ast.GenDecl)
+		if !ok {
+			return
 		}
 
-		if init, ok := v.Init.(*ast.AssignStmt); ok {
-			for _, e := range init.Rhs {
-				nom.checkExpression(e, v.If)
+		for _, spec := range genDecl.Specs {
+			switch spec := spec.(type) {
+			case *ast.ValueSpec:
+				oom.checkExpression(pass, spec.Type, ifPos)
+				for _, el := range spec.Values {
+					oom.checkExpression(pass, el, ifPos)
+				}
+			case *ast.TypeSpec:
+				oom.checkFieldList(pass, spec.TypeParams, ifPos)
+				oom.checkExpression(pass, spec.Type, ifPos)
 			}
 		}
+	case *ast.DeferStmt:
+		oom.checkExpression(pass, v.Call, ifPos)
+	case *ast.ExprStmt:
+		oom.checkExpression(pass, v.X, ifPos)
+	case *ast.ForStmt:
+		oom.checkStatement(pass, v.Init, ifPos)
+		oom.checkExpression(pass, v.Cond, ifPos)
+		oom.checkStatement(pass, v.Post, ifPos)
+		oom.checkStatement(pass, v.Body, ifPos)
+	case *ast.GoStmt:
+		oom.checkExpression(pass, v.Call, ifPos)
+	case *ast.IfStmt:
+		oom.checkIfStmt(pass, v, v.If)
 	case *ast.IncDecStmt:
-		nom.checkExpression(v.X, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
+	case *ast.LabeledStmt:
+		oom.checkStatement(pass, v.Stmt, ifPos)
 	case *ast.RangeStmt:
-		nom.checkExpression(v.X, ifPos)
-		if v.Body != nil {
-			for _, e := range v.Body.List {
-				nom.checkStatement(e, ifPos)
-			}
+		if v.Tok == token.ASSIGN {
+			oom.checkExpression(pass, v.Key, ifPos)
+			oom.checkExpression(pass, v.Value, ifPos)
 		}
+		oom.checkExpression(pass, v.X, ifPos)
+		oom.checkStatement(pass, v.Body, ifPos)
 	case *ast.ReturnStmt:
 		for _, r := range v.Results {
-			nom.checkExpression(r, ifPos)
+			oom.checkExpression(pass, r, ifPos)
 		}
+	case *ast.SelectStmt:
+		oom.checkStatement(pass, v.Body, ifPos)
 	case *ast.SendStmt:
-		nom.checkExpression(v.Chan, ifPos)
-		nom.checkExpression(v.Value, ifPos)
+		oom.checkExpression(pass, v.Chan, ifPos)
+		oom.checkExpression(pass, v.Value, ifPos)
 	case *ast.SwitchStmt:
-		nom.checkExpression(v.Tag, ifPos)
-
-		for _, el := range v.Body.List {
-			clauses, ok := el.(*ast.CaseClause)
-			if !ok {
-				continue
-			}
+		oom.checkStatement(pass, v.Init, ifPos)
+		oom.checkExpression(pass, v.Tag, oom.getSwitchPos(v.Switch, ifPos))
+		oom.checkStatement(pass, v.Body, ifPos)
+	case *ast.TypeSwitchStmt:
+		switchPos := oom.getSwitchPos(v.Switch, ifPos)
 
-			for _, c := range clauses.List {
-				switch v := c.(type) {
-				case *ast.BinaryExpr:
-					nom.checkExpression(v.X, ifPos)
-					nom.checkExpression(v.Y, ifPos)
-				case *ast.Ident:
-					nom.checkExpression(v, ifPos)
-				}
-			}
+		oom.checkStatement(pass, v.Init, ifPos)
 
-			for _, c := range clauses.Body {
-				switch v := c.(type) {
-				case *ast.AssignStmt:
-					for _, el := range v.Lhs {
-						nom.checkExpression(el, ifPos)
-					}
-					for _, el := range v.Rhs {
-						nom.checkExpression(el, ifPos)
-					}
-				case *ast.ExprStmt:
-					nom.checkExpression(v.X, ifPos)
-				}
-			}
+		switch assign := v.Assign.(type) {
+		case *ast.AssignStmt:
+			oom.checkStatement(pass, assign, switchPos)
+		case *ast.ExprStmt:
+			oom.checkExpression(pass, assign.X, switchPos)
 		}
-	case *ast.SelectStmt:
-		for _, el := range v.Body.List {
-			clause := el.(*ast.CommClause)
 
-			nom.checkStatement(clause.Comm, ifPos)
+		oom.checkStatement(pass, v.Body, ifPos)
+	case *ast.BadStmt, *ast.BranchStmt, *ast.EmptyStmt:
+		// These statements don't reference variables.
+	}
+}
 
-			for _, c := range clause.Body {
-				switch v := c.(type) {
-				case *ast.AssignStmt:
-					for _, el := range v.Lhs {
-						nom.checkExpression(el, ifPos)
-					}
-					for _, el := range v.Rhs {
-						nom.checkExpression(el, ifPos)
-					}
-				case *ast.ExprStmt:
-					nom.checkExpression(v.X, ifPos)
-				}
-			}
-		}
-	case *ast.LabeledStmt:
-		nom.checkStatement(v.Stmt, ifPos)
+// checkIfStmt checks the if-statement as a part of the one at ifPos:
+// the else-if clauses belong to the if-statement that starts the chain.
+func (oom objectOccurrenceMap) checkIfStmt(pass *analysis.Pass, stmt *ast.IfStmt, ifPos token.Pos) {
+	oom.checkStatement(pass, stmt.Init, ifPos)
+	oom.checkExpression(pass, stmt.Cond, ifPos)
+	oom.checkStatement(pass, stmt.Body, ifPos)
+
+	if elseIf, ok := stmt.Else.(*ast.IfStmt); ok {
+		oom.checkIfStmt(pass, elseIf, ifPos)
+	} else {
+		oom.checkStatement(pass, stmt.Else, ifPos)
 	}
 }
 
-func (nom namedOccurrenceMap) checkExpression(candidate ast.Expr, ifPos token.Pos) {
+// checkExpression removes the occurrences of variables that are referenced in the expression
+// outside of the if-statement at ifPos. Every kind of ast.Expr is walked through completely.
+func (oom objectOccurrenceMap) checkExpression(pass *analysis.Pass, candidate ast.Expr, ifPos token.Pos) {
 	switch v := candidate.(type) {
+	case *ast.ArrayType:
+		oom.checkExpression(pass, v.Len, ifPos)
+		oom.checkExpression(pass, v.Elt, ifPos)
 	case *ast.BinaryExpr:
-		nom.checkExpression(v.X, ifPos)
-		nom.checkExpression(v.Y, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
+		oom.checkExpression(pass, v.Y, ifPos)
 	case *ast.CallExpr:
+		oom.checkExpression(pass, v.Fun, ifPos)
 		for _, arg := range v.Args {
-			nom.checkExpression(arg, ifPos)
-		}
-		nom.checkExpression(v.Fun, ifPos)
-		if fun, ok := v.Fun.(*ast.SelectorExpr); ok {
-			nom.checkExpression(fun.X, ifPos)
+			oom.checkExpression(pass, arg, ifPos)
 		}
+	case *ast.ChanType:
+		oom.checkExpression(pass, v.Value, ifPos)
 	case *ast.CompositeLit:
+		oom.checkExpression(pass, v.Type, ifPos)
 		for _, el := range v.Elts {
-			switch v := el.(type) {
-			case *ast.Ident, *ast.CompositeLit:
-				nom.checkExpression(v, ifPos)
-			case *ast.KeyValueExpr:
-				nom.checkExpression(v.Key, ifPos)
-				nom.checkExpression(v.Value, ifPos)
-			case *ast.SelectorExpr:
-				nom.checkExpression(v.X, ifPos)
-			}
+			oom.checkExpression(pass, el, ifPos)
 		}
+	case *ast.Ellipsis:
+		oom.checkExpression(pass, v.Elt, ifPos)
 	case *ast.FuncLit:
-		for _, el := range v.Body.List {
-			nom.checkStatement(el, ifPos)
-		}
+		oom.checkExpression(pass, v.Type, ifPos)
+		oom.checkStatement(pass, v.Body, ifPos)
+	case *ast.FuncType:
+		oom.checkFieldList(pass, v.Params, ifPos)
+		oom.checkFieldList(pass, v.Results, ifPos)
 	case *ast.Ident:
-		if _, ok := nom[v.Name]; !ok || nom[v.Name].isEmponymousKey(ifPos) {
+		obj := pass.TypesInfo.Uses[v]
+		if _, ok := oom[obj]; !ok || oom[obj].isEmponymousKey(ifPos) {
 			return
 		}
 
-		scopeMarker1 := nom[v.Name].getScopeMarkerForPosition(v.Pos())
+		scopeMarker1 := oom[obj].getScopeMarkerForPosition(v.Pos())
 
-		delete(nom[v.Name], scopeMarker1)
+		delete(oom[obj], scopeMarker1)
 
-		for k := range nom {
-			for scopeMarker2 := range nom[k] {
+		for k := range oom {
+			for scopeMarker2 := range oom[k] {
 				if scopeMarker1 == scopeMarker2 {
-					delete(nom[k], scopeMarker2)
+					delete(oom[k], scopeMarker2)
 				}
 			}
 		}
-	case *ast.StarExpr:
-		nom.checkExpression(v.X, ifPos)
 	case *ast.IndexExpr:
-		nom.checkExpression(v.X, ifPos)
-		switch index := v.Index.(type) {
-		case *ast.BinaryExpr:
-			nom.checkExpression(index.X, ifPos)
-		case *ast.Ident:
-			nom.checkExpression(index, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
+		oom.checkExpression(pass, v.Index, ifPos)
+	case *ast.IndexListExpr:
+		oom.checkExpression(pass, v.X, ifPos)
+		for _, index := range v.Indices {
+			oom.checkExpression(pass, index, ifPos)
 		}
+	case *ast.InterfaceType:
+		oom.checkFieldList(pass, v.Methods, ifPos)
+	case *ast.KeyValueExpr:
+		oom.checkExpression(pass, v.Key, ifPos)
+		oom.checkExpression(pass, v.Value, ifPos)
+	case *ast.MapType:
+		oom.checkExpression(pass, v.Key, ifPos)
+		oom.checkExpression(pass, v.Value, ifPos)
+	case *ast.ParenExpr:
+		oom.checkExpression(pass, v.X, ifPos)
 	case *ast.SelectorExpr:
-		nom.checkExpression(v.X, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
 	case *ast.SliceExpr:
-		nom.checkExpression(v.High, ifPos)
-		nom.checkExpression(v.Low, ifPos)
-		nom.checkExpression(v.X, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
+		oom.checkExpression(pass, v.Low, ifPos)
+		oom.checkExpression(pass, v.High, ifPos)
+		oom.checkExpression(pass, v.Max, ifPos)
+	case *ast.StarExpr:
+		oom.checkExpression(pass, v.X, ifPos)
+	case *ast.StructType:
+		oom.checkFieldList(pass, v.Fields, ifPos)
 	case *ast.TypeAssertExpr:
-		nom.checkExpression(v.X, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
+		oom.checkExpression(pass, v.Type, ifPos)
 	case *ast.UnaryExpr:
-		nom.checkExpression(v.X, ifPos)
+		oom.checkExpression(pass, v.X, ifPos)
+	case *ast.BadExpr, *ast.BasicLit:
+		// These expressions don't reference variables.
+	}
+}
+
+// checkFieldList checks the types of the fields, which may reference variables, e.g. in unsafe.Sizeof of an array length.
+func (oom objectOccurrenceMap) checkFieldList(pass *analysis.Pass, fields *ast.FieldList, ifPos token.Pos) {
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		oom.checkExpression(pass, field.Type, ifPos)
+	}
+}
+
+// getSwitchPos returns the position that references in the tag of switch-statement are checked against:
+// its own position if some variable is only used there, and position of the enclosing if-statement otherwise.
+func (oom objectOccurrenceMap) getSwitchPos(switchPos, ifPos token.Pos) token.Pos {
+	for _, markeredOccs := range oom {
+		if markeredOccs.isEmponymousKey(switchPos) {
+			return switchPos
+		}
+	}
+	return ifPos
+}
+
+// isUsedAfter checks if the object of the identifier is referenced anywhere after it.
+func isUsedAfter(pass *analysis.Pass, ident *ast.Ident) bool {
+	obj := pass.TypesInfo.Uses[ident]
+
+	for id, o := range pass.TypesInfo.Uses {
+		if o == obj && id.Pos() > ident.Pos() {
+			return true
+		}
 	}
+	return false
 }
 
 func isAssign(tok token.Token) bool {
@@ -9,12 +9,137 @@ import (
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
-func TestAll(t *testing.T) {
+// testdataDir returns the path of the testdata directory at the root of the repository, joined with elems.
+func testdataDir(t *testing.T, elems ...string) string {
+	t.Helper()
+
 	wd, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("Failed to get wd: %s", err)
 	}
+	return filepath.Join(append([]string{filepath.Dir(filepath.Dir(wd)), "testdata"}, elems...)...)
+}
+
+func TestAll(t *testing.T) {
+	analysistest.Run(t, testdataDir(t), analyzer.Analyzer)
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, testdataDir(t, "fixes"), analyzer.Analyzer)
+}
+
+func TestCheckSwitch(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.CheckSwitch = true
+
+	analysistest.RunWithSuggestedFixes(t, testdataDir(t, "switch"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestCheckLongInit(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.CheckLongInit = true
+
+	analysistest.Run(t, testdataDir(t, "longinit"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestNewAnalyzer(t *testing.T) {
+	a := analyzer.NewAnalyzer(analyzer.DefaultConfig())
+	if err := a.Flags.Set("check-switch", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %s", err)
+	}
 
-	testdata := filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata")
+	if v := analyzer.Analyzer.Flags.Lookup("check-switch").Value.String(); v != "false" {
+		t.Fatalf("Flag of the default analyzer is changed: %s", v)
+	}
+
+	testdata := testdataDir(t)
+	analysistest.Run(t, filepath.Join(testdata, "switch"), a)
 	analysistest.Run(t, testdata, analyzer.Analyzer)
 }
+
+func TestDirectives(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.ReportUnusedDirectives = true
+
+	analysistest.Run(t, testdataDir(t, "directives"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestSkippedFiles(t *testing.T) {
+	testdata := testdataDir(t, "generated")
+
+	cfg := analyzer.DefaultConfig()
+	cfg.ExcludePaths = `_mock\.go$`
+	analysistest.Run(t, filepath.Join(testdata, "skipped"), analyzer.NewAnalyzer(cfg))
+
+	cfg = analyzer.DefaultConfig()
+	cfg.IncludeGenerated = true
+	analysistest.Run(t, filepath.Join(testdata, "included"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestGenerics(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, testdataDir(t, "generics"), analyzer.Analyzer)
+}
+
+func TestVerifyFixes(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.VerifyFixes = true
+
+	analysistest.RunWithSuggestedFixes(t, testdataDir(t, "fixes"), analyzer.NewAnalyzer(cfg))
+	analysistest.Run(t, testdataDir(t, "verify"), analyzer.NewAnalyzer(cfg))
+
+	// The verification doesn't change anything for the fixes the analysis already knows to be safe.
+	analysistest.Run(t, testdataDir(t, "verify"), analyzer.Analyzer)
+}
+
+func TestVars(t *testing.T) {
+	for _, vars := range []string{"^(err|ok)$", "err,ok", " err , ok "} {
+		cfg := analyzer.DefaultConfig()
+		cfg.Vars = vars
+
+		analysistest.Run(t, testdataDir(t, "vars"), analyzer.NewAnalyzer(cfg))
+	}
+}
+
+func TestIdiomaticOnly(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.IdiomaticOnly = true
+
+	analysistest.Run(t, testdataDir(t, "idiomatic"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestExclude(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.ExcludeTypes = "context.CancelFunc, *sync.Mutex"
+	cfg.ExcludeCallees = "context.Background,bytes.Buffer.String,io.Reader.Read"
+
+	analysistest.Run(t, testdataDir(t, "exclude"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestDeclLengthMode(t *testing.T) {
+	testdata := testdataDir(t, "length")
+
+	cfg := analyzer.DefaultConfig()
+	cfg.DeclLengthMode = analyzer.LengthModeRunes
+	analysistest.Run(t, filepath.Join(testdata, "runes"), analyzer.NewAnalyzer(cfg))
+
+	cfg = analyzer.DefaultConfig()
+	cfg.DeclLengthMode = analyzer.LengthModeColumns
+	cfg.TabWidth = 8
+	analysistest.Run(t, filepath.Join(testdata, "columns"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestMaxLineLength(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.DeclLengthMode = analyzer.LengthModeRunes
+	cfg.MaxLineLength = 31
+
+	analysistest.Run(t, testdataDir(t, "length", "lines"), analyzer.NewAnalyzer(cfg))
+}
+
+func TestMaxIfLineChars(t *testing.T) {
+	cfg := analyzer.DefaultConfig()
+	cfg.CheckSwitch = true
+	cfg.MaxIfLineChars = 40
+
+	analysistest.Run(t, testdataDir(t, "header"), analyzer.NewAnalyzer(cfg))
+}
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Config is a configuration of ifshort linter.
+// The JSON names of its fields are the same as the names of the flags.
+type Config struct {
+	// MaxDeclLines is the maximum length of variable declaration measured in number of lines,
+	// after which the linter won't suggest using short syntax. Has precedence over MaxDeclChars.
+	MaxDeclLines int `json:"max-decl-lines"`
+	// MaxDeclChars is the maximum length of variable declaration measured in number of characters,
+	// after which the linter won't suggest using short syntax.
+	MaxDeclChars int `json:"max-decl-chars"`
+	// CheckSwitch enables suggestions of short syntax for switch-statements.
+	CheckSwitch bool `json:"check-switch"`
+	// CheckLongInit enables reporting of if-statement init clauses that exceed MaxDeclLines or MaxDeclChars.
+	CheckLongInit bool `json:"check-long-init"`
+	// ReportUnusedDirectives enables reporting of //nolint:ifshort and //ifshort:ignore directives that don't suppress anything.
+	ReportUnusedDirectives bool `json:"report-unused-directives"`
+	// IncludeGenerated enables analysis of the files having the standard "Code generated ... DO NOT EDIT." comment.
+	IncludeGenerated bool `json:"include-generated"`
+	// ExcludePaths is a regular expression matching paths of the files that shouldn't be analyzed.
+	ExcludePaths string `json:"exclude-paths"`
+	// VerifyFixes disables reporting of the variables whose suggested fix wouldn't type-check.
+	// Every fix is applied to an in-memory copy of its file and the package is type-checked again,
+	// which slows the analysis down considerably. The diagnostics without a fix are reported as is.
+	VerifyFixes bool `json:"verify-fixes"`
+	// Vars selects the variables to suggest short syntax for by name: either a comma-separated list of names, e.g. "err,ok",
+	// or a regular expression matching them, e.g. "^(err|ok)$". A value made only of names and commas is treated as a list.
+	Vars string `json:"vars"`
+	// IdiomaticOnly restricts the suggestions of short syntax to the variables whose type implements error,
+	// and to the boolean results of comma-ok expressions, i.e. of map index, type assertion and receive operation.
+	IdiomaticOnly bool `json:"idiomatic-only"`
+	// ExcludeTypes is a comma-separated list of fully qualified types of the variables not to suggest short syntax for,
+	// e.g. "context.CancelFunc,*sync.Mutex".
+	ExcludeTypes string `json:"exclude-types"`
+	// ExcludeCallees is a comma-separated list of fully qualified functions and methods, e.g. "go.opentelemetry.io/otel/trace.Tracer.Start".
+	// Short syntax isn't suggested for the variables declared with their results.
+	ExcludeCallees string `json:"exclude-callees"`
+	// DeclLengthMode is how the length of declarations and lines is measured: LengthModeBytes, LengthModeRunes or LengthModeColumns.
+	DeclLengthMode string `json:"decl-length-mode"`
+	// TabWidth is the width of tab in columns, used if DeclLengthMode is LengthModeColumns.
+	TabWidth int `json:"tab-width"`
+	// MaxLineLength is the maximum length of the first line of if-statement with the declaration moved into it,
+	// after which the linter won't suggest using short syntax. It's only checked if the fix is suggested, 0 means no limit.
+	MaxLineLength int `json:"max-line-length"`
+	// MaxIfLineChars is the maximum length of the header of if-statement with the declaration moved into it,
+	// as printed by go/printer, after which the linter won't suggest using short syntax. 0 means no limit.
+	MaxIfLineChars int `json:"max-if-line-chars"`
+}
+
+// DefaultConfig returns the configuration that Analyzer is created with.
+func DefaultConfig() Config {
+	return Config{
+		MaxDeclLines:   1,
+		MaxDeclChars:   30,
+		DeclLengthMode: LengthModeBytes,
+		TabWidth:       1,
+	}
+}
+
+// Decode sets the fields of the configuration from the settings named the same as the flags,
+// e.g. decoded from a configuration file. The fields that aren't set are left intact,
+// and the unknown settings are reported as an error.
+func (cfg *Config) Decode(settings any) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(settings); err != nil {
+		return fmt.Errorf("encoding settings: %w", err)
+	}
+
+	dec := json.NewDecoder(&buf)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("decoding settings: %w", err)
+	}
+	return nil
+}
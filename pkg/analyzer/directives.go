@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	nolintRe = regexp.MustCompile(`^//\s?nolint(?::(\S+))?(?:\s|$)`)
+	ignoreRe = regexp.MustCompile(`^//\s?ifshort:ignore(?:\s|$)`)
+)
+
+// directive is a comment that suppresses the reports of the linter.
+// It's either placed on the line of the declaration or the if-statement, in the doc comment of a function,
+// or before the package clause, in which case it applies to the whole file.
+type directive struct {
+	text string
+	pos  token.Pos
+	// from and to are set for the function- and file-level directives, which apply to all positions in between.
+	from, to token.Pos
+	// explicit is set if the directive refers to ifshort by name, unlike a bare //nolint.
+	explicit bool
+	used     bool
+}
+
+// getDirectives returns the directives found in the comments of the files.
+func getDirectives(pass *analysis.Pass, files []*ast.File) []*directive {
+	var directives []*directive
+
+	for _, file := range files {
+		funcDocs := map[*ast.CommentGroup]*ast.FuncDecl{}
+
+		for _, decl := range file.Decls {
+			if fdecl, ok := decl.(*ast.FuncDecl); ok && fdecl.Doc != nil {
+				funcDocs[fdecl.Doc] = fdecl
+			}
+		}
+
+		for _, cg := range file.Comments {
+			for _, c := range cg.List {
+				d := parseDirective(c)
+				if d == nil {
+					continue
+				}
+
+				if fdecl, ok := funcDocs[cg]; ok {
+					d.from, d.to = fdecl.Pos(), fdecl.End()
+				} else if cg.End() < file.Package {
+					tokFile := pass.Fset.File(file.Package)
+					d.from, d.to = token.Pos(tokFile.Base()), token.Pos(tokFile.Base()+tokFile.Size())
+				}
+				directives = append(directives, d)
+			}
+		}
+	}
+	return directives
+}
+
+func parseDirective(c *ast.Comment) *directive {
+	if ignoreRe.MatchString(c.Text) {
+		return &directive{text: c.Text, pos: c.Pos(), explicit: true}
+	}
+
+	match := nolintRe.FindStringSubmatch(c.Text)
+	if match == nil {
+		return nil
+	}
+	if match[1] == "" {
+		return &directive{text: c.Text, pos: c.Pos()}
+	}
+
+	for _, linter := range strings.Split(match[1], ",") {
+		if linter == "ifshort" {
+			return &directive{text: c.Text, pos: c.Pos(), explicit: true}
+		}
+	}
+	return nil
+}
+
+// appliesTo checks if the directive suppresses the reports at pos.
+func (d *directive) appliesTo(fset *token.FileSet, pos token.Pos) bool {
+	if d.from != token.NoPos {
+		return d.from <= pos && pos <= d.to
+	}
+
+	dPos, p := fset.Position(d.pos), fset.Position(pos)
+	return dPos.Filename == p.Filename && dPos.Line == p.Line
+}
+
+// reporter reports diagnostics, unless they are suppressed by directives.
+type reporter struct {
+	pass       *analysis.Pass
+	directives []*directive
+}
+
+// report reports the diagnostic, unless it's suppressed by a directive at any of the positions it refers to.
+func (r *reporter) report(diag analysis.Diagnostic, positions ...token.Pos) {
+	positions = append(positions, diag.Pos)
+	suppressed := false
+
+	for _, d := range r.directives {
+		for _, pos := range positions {
+			if d.appliesTo(r.pass.Fset, pos) {
+				d.used = true
+				suppressed = true
+			}
+		}
+	}
+
+	if !suppressed {
+		r.pass.Report(diag)
+	}
+}
+
+// reportUnusedDirectives reports the directives referring to ifshort that didn't suppress anything.
+func (r *reporter) reportUnusedDirectives() {
+	for _, d := range r.directives {
+		if d.explicit && !d.used {
+			r.pass.Report(analysis.Diagnostic{
+				Pos:      d.pos,
+				End:      d.pos + token.Pos(len(d.text)),
+				Category: CategoryUnusedDirective,
+				Message:  fmt.Sprintf("directive '%s' is unused for ifshort", d.text),
+			})
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package analyzer_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/esimonov/ifshort/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const expressionsSrc = `package p
+
+import "unsafe"
+
+type dummy struct {
+	f interface{}
+	s []interface{}
+}
+
+func (dummy) m() {}
+
+func getDummy(...interface{}) dummy { return dummy{} }
+
+func getValue(...interface{}) interface{} { return nil }
+
+func pair[A, B any](a A, b B) B { return b }
+
+func f() {
+	v := getDummy()
+	if v.f != nil {
+		return
+	}
+	_ = %s
+}
+
+var _ unsafe.Pointer
+`
+
+// TestExpressions checks that a variable referenced after the if-statement in any kind of ast.Expr isn't reported.
+func TestExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		reported bool
+	}{
+		{name: "ArrayType", expr: "[unsafe.Sizeof(v)]int{}"},
+		{name: "BasicLit", expr: "0", reported: true},
+		{name: "BinaryExpr", expr: "v.f == nil"},
+		{name: "BinaryExpr_Y", expr: "nil == v.f"},
+		{name: "CallExpr_Arg", expr: "getValue(v)"},
+		{name: "CallExpr_Ellipsis", expr: "getValue(v.s...)"},
+		{name: "CallExpr_Fun", expr: "v.m"},
+		{name: "ChanType", expr: "make(chan [unsafe.Sizeof(v)]int)"},
+		{name: "CompositeLit_Ident", expr: "[]interface{}{v}"},
+		{name: "CompositeLit_Call", expr: "[]interface{}{getValue(v)}"},
+		{name: "CompositeLit_Binary", expr: "[]bool{v.f == nil}"},
+		{name: "CompositeLit_Unary", expr: "[]*dummy{&v}"},
+		{name: "CompositeLit_Index", expr: "[]interface{}{v.s[0]}"},
+		{name: "CompositeLit_Type", expr: "[unsafe.Sizeof(v)]int{}"},
+		{name: "Ellipsis", expr: "[...]interface{}{v}"},
+		{name: "Ellipsis_Elt", expr: "func(...[unsafe.Sizeof(v)]int) {}"},
+		{name: "FuncLit", expr: "func() interface{} { return v }"},
+		{name: "FuncType", expr: "func([unsafe.Sizeof(v)]int) {}"},
+		{name: "Ident", expr: "v"},
+		{name: "IndexExpr_X", expr: "v.s[0]"},
+		{name: "IndexExpr_Index", expr: "map[interface{}]int{}[v.f]"},
+		{name: "IndexExpr_IndexBinaryY", expr: "[]int{}[1+len(v.s)]"},
+		{name: "IndexExpr_IndexCall", expr: "[]int{}[len(v.s)]"},
+		{name: "IndexListExpr", expr: "pair[int, dummy](0, v)"},
+		{name: "InterfaceType", expr: "interface{ m([unsafe.Sizeof(v)]int) }(nil)"},
+		{name: "KeyValueExpr_Key", expr: "map[interface{}]int{v.f: 0}"},
+		{name: "KeyValueExpr_Value", expr: "dummy{f: v}"},
+		{name: "MapType", expr: "map[[unsafe.Sizeof(v)]int]int{}"},
+		{name: "ParenExpr", expr: "(v)"},
+		{name: "SelectorExpr", expr: "v.f"},
+		{name: "SliceExpr_X", expr: "v.s[:]"},
+		{name: "SliceExpr_Low", expr: "[]int{}[len(v.s):]"},
+		{name: "SliceExpr_High", expr: "[]int{}[:len(v.s)]"},
+		{name: "SliceExpr_Max", expr: "[]int{}[0:0:len(v.s)]"},
+		{name: "StarExpr", expr: "*&v"},
+		{name: "StructType", expr: "struct{ a [unsafe.Sizeof(v)]int }{}"},
+		{name: "TypeAssertExpr", expr: "interface{}(v).(dummy)"},
+		{name: "UnaryExpr", expr: "&v"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := runOnSource(t, fmt.Sprintf(expressionsSrc, tt.expr))
+
+			if reported := len(diagnostics) != 0; reported != tt.reported {
+				t.Errorf("Expected reported to be %t, got diagnostics: %v", tt.reported, diagnostics)
+			}
+		})
+	}
+}
+
+func runOnSource(t *testing.T, src string) []analysis.Diagnostic {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+
+	info := &types.Info{
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+		Types: map[ast.Expr]types.TypeAndValue{},
+	}
+
+	cfg := &types.Config{Importer: importer.Default()}
+
+	pkg, err := cfg.Check("p", fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatalf("Failed to type-check: %s", err)
+	}
+
+	var diagnostics []analysis.Diagnostic
+
+	pass := &analysis.Pass{
+		Analyzer:  analyzer.Analyzer,
+		Fset:      fset,
+		Files:     []*ast.File{file},
+		Pkg:       pkg,
+		TypesInfo: info,
+		ResultOf: map[*analysis.Analyzer]interface{}{
+			inspect.Analyzer: inspector.New([]*ast.File{file}),
+		},
+		Report: func(d analysis.Diagnostic) {
+			diagnostics = append(diagnostics, d)
+		},
+	}
+
+	if _, err := analyzer.Analyzer.Run(pass); err != nil {
+		t.Fatalf("Failed to run: %s", err)
+	}
+	return diagnostics
+}
@@ -0,0 +1,56 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var generatedRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// getSkippedFiles returns the files that shouldn't be analyzed:
+// generated ones, unless IncludeGenerated is set, and the ones matching ExcludePaths.
+func (cfg *Config) getSkippedFiles(pass *analysis.Pass) (map[*token.File]bool, error) {
+	var excludeRe *regexp.Regexp
+
+	if cfg.ExcludePaths != "" {
+		var err error
+		if excludeRe, err = regexp.Compile(cfg.ExcludePaths); err != nil {
+			return nil, fmt.Errorf("invalid exclude-paths: %w", err)
+		}
+	}
+
+	skipped := map[*token.File]bool{}
+
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Package)
+
+		if !cfg.IncludeGenerated && isGenerated(file) {
+			skipped[tokFile] = true
+		}
+		if excludeRe != nil && excludeRe.MatchString(filepath.ToSlash(tokFile.Name())) {
+			skipped[tokFile] = true
+		}
+	}
+	return skipped, nil
+}
+
+// isGenerated checks if the file has the standard "Code generated ... DO NOT EDIT." comment before the package clause.
+func isGenerated(file *ast.File) bool {
+	for _, cg := range file.Comments {
+		if cg.Pos() > file.Package {
+			break
+		}
+
+		for _, c := range cg.List {
+			if generatedRe.MatchString(c.Text) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// varFilter selects the variables that short syntax may be suggested for.
+// The variables it doesn't select are still tracked, as they share the declaration with the selected ones.
+type varFilter struct {
+	names          *regexp.Regexp
+	idiomatic      bool
+	excludeTypes   map[string]bool
+	excludeCallees map[string]bool
+}
+
+// getVarFilter returns the filter of variables made of the configuration.
+func (cfg *Config) getVarFilter() (*varFilter, error) {
+	filter := &varFilter{
+		idiomatic:      cfg.IdiomaticOnly,
+		excludeTypes:   splitList(cfg.ExcludeTypes),
+		excludeCallees: splitList(cfg.ExcludeCallees),
+	}
+
+	if cfg.Vars != "" {
+		var err error
+		if filter.names, err = compileVars(cfg.Vars); err != nil {
+			return nil, fmt.Errorf("invalid vars: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+// namesListRe matches a comma-separated list of names, which has no regular expression metacharacters.
+var namesListRe = regexp.MustCompile(`^\s*\w+\s*(?:,\s*\w+\s*)*$`)
+
+// compileVars compiles the value of vars: either a comma-separated list of exact names, e.g. "err,ok",
+// or a regular expression, e.g. "^(err|ok)$".
+func compileVars(vars string) (*regexp.Regexp, error) {
+	if !namesListRe.MatchString(vars) {
+		return regexp.Compile(vars)
+	}
+
+	names := strings.Split(vars, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	return regexp.Compile("^(?:" + strings.Join(names, "|") + ")$")
+}
+
+// isSelected checks if the i-th variable declared by the assignment is selected by the filter.
+func (f *varFilter) isSelected(pass *analysis.Pass, assignment *ast.AssignStmt, i int, obj types.Object) bool {
+	if f.names != nil && !f.names.MatchString(obj.Name()) {
+		return false
+	}
+	if f.idiomatic && !types.Implements(obj.Type(), errorType) && !isCommaOk(pass, assignment, i) {
+		return false
+	}
+	if f.excludeTypes[types.TypeString(obj.Type(), nil)] {
+		return false
+	}
+	if len(f.excludeCallees) != 0 && f.excludeCallees[getCalleeName(pass, assignment, i)] {
+		return false
+	}
+	return true
+}
+
+// getCalleeName returns the fully qualified name of the function called on the right-hand side
+// of the i-th variable declared by the assignment, e.g. "context.WithTimeout" or "sync.Mutex.Lock"
+// for methods, regardless of the receiver being a pointer. If it's not a call, an empty string is returned.
+func getCalleeName(pass *analysis.Pass, assignment *ast.AssignStmt, i int) string {
+	rh := assignment.Rhs[len(assignment.Rhs)-1]
+	if len(assignment.Rhs) == len(assignment.Lhs) {
+		rh = assignment.Rhs[i]
+	}
+
+	call, ok := ast.Unparen(rh).(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	fn = fn.Origin()
+
+	name := fn.Pkg().Path() + "."
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			name += named.Obj().Name() + "."
+		}
+	}
+	return name + fn.Name()
+}
+
+// splitList splits the comma-separated list into a set, skipping the empty elements.
+func splitList(list string) map[string]bool {
+	set := map[string]bool{}
+
+	for _, el := range strings.Split(list, ",") {
+		if el = strings.TrimSpace(el); el != "" {
+			set[el] = true
+		}
+	}
+	return set
+}
+
+// isCommaOk checks if the i-th variable declared by the assignment is the boolean result of a comma-ok expression,
+// i.e. of a map index, a type assertion or a receive operation.
+func isCommaOk(pass *analysis.Pass, assignment *ast.AssignStmt, i int) bool {
+	if len(assignment.Lhs) != 2 || len(assignment.Rhs) != 1 || i != 1 {
+		return false
+	}
+
+	switch v := ast.Unparen(assignment.Rhs[0]).(type) {
+	case *ast.IndexExpr:
+		_, ok := pass.TypesInfo.TypeOf(v.X).Underlying().(*types.Map)
+		return ok
+	case *ast.TypeAssertExpr:
+		return true
+	case *ast.UnaryExpr:
+		return v.Op == token.ARROW
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// getSuggestedFixes returns a fix that moves the declaration of the occurrence into the init clause of its if- or switch-statement.
+// If such a rewrite can't be done safely, nil is returned and the diagnostic is reported without a fix.
+func getSuggestedFixes(pass *analysis.Pass, occ occurrence) []analysis.SuggestedFix {
+	file, assignment, stmt := getDeclaration(pass, occ)
+	if assignment == nil {
+		return nil
+	}
+
+	initPos := getInitPos(stmt, occ.ifStmtPos)
+	if initPos == token.NoPos {
+		return nil
+	}
+
+	// Comments inside of the declaration would be lost by reformatting it.
+	for _, cg := range file.Comments {
+		if cg.Pos() > assignment.Pos() && cg.End() < assignment.End() {
+			return nil
+		}
+	}
+
+	init, err := formatInit(pass.Fset, assignment)
+	if err != nil {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Move '%s' into the %s-statement", init, occ.stmtTok),
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos: assignment.Pos(),
+				End: getDeletionEnd(pass, file, assignment, stmt),
+			},
+			{
+				Pos:     initPos,
+				End:     initPos,
+				NewText: append(init, "; "...),
+			},
+		},
+	}}
+}
+
+// getDeclaration returns the short variable declaration of the occurrence, the file it's in,
+// and the statement following it. If the variable isn't declared with :=, the assignment is nil.
+func getDeclaration(pass *analysis.Pass, occ occurrence) (*ast.File, *ast.AssignStmt, ast.Stmt) {
+	file := getFile(pass, occ.declarationPos)
+	if file == nil {
+		return nil, nil, nil
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, occ.declarationPos, occ.declarationPos)
+
+	for i, node := range path {
+		if a, ok := node.(*ast.AssignStmt); ok && i+1 < len(path) {
+			if a.Tok != token.DEFINE {
+				break
+			}
+			return file, a, getNextStmt(getStmtList(path[i+1]), a)
+		}
+	}
+	return file, nil, nil
+}
+
+// formatInit formats the assignment to be used as an init clause.
+// Composite literals of named types are ambiguous there, e.g. `if v := T{}; v.ok {`,
+// so the right-hand side is parenthesized if the init clause can't be parsed as is.
+// A declaration spanning several lines is collapsed onto one, as its continuation lines
+// would be indented for the declaration rather than for the statement.
+func formatInit(fset *token.FileSet, assignment *ast.AssignStmt) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, assignment); err != nil {
+		return nil, err
+	}
+
+	if bytes.IndexByte(buf.Bytes(), '\n') >= 0 {
+		// Without the positions of the nodes, the printer has no line breaks to preserve.
+		fset = token.NewFileSet()
+
+		buf.Reset()
+		if err := format.Node(&buf, fset, assignment); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "", "package p; func _() { if "+buf.String()+"; true {} }", 0); err == nil {
+		return buf.Bytes(), nil
+	}
+
+	parenthesized := *assignment
+	parenthesized.Rhs = make([]ast.Expr, 0, len(assignment.Rhs))
+
+	for _, el := range assignment.Rhs {
+		parenthesized.Rhs = append(parenthesized.Rhs, &ast.ParenExpr{X: el})
+	}
+
+	buf.Reset()
+	if err := format.Node(&buf, fset, &parenthesized); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getDeletionEnd returns the position up to which the source should be deleted along with the declaration:
+// its trailing comment and the blank lines after it, but not the comments standing on their own lines.
+func getDeletionEnd(pass *analysis.Pass, file *ast.File, assignment *ast.AssignStmt, stmt ast.Stmt) token.Pos {
+	declLine := pass.Fset.Position(assignment.End()).Line
+
+	for _, cg := range file.Comments {
+		if cg.Pos() < assignment.End() || cg.Pos() > stmt.Pos() {
+			continue
+		}
+		if pass.Fset.Position(cg.Pos()).Line > declLine {
+			return cg.Pos()
+		}
+	}
+	return stmt.Pos()
+}
+
+func getFile(pass *analysis.Pass, pos token.Pos) *ast.File {
+	tokFile := pass.Fset.File(pos)
+
+	for _, file := range pass.Files {
+		if pass.Fset.File(file.Pos()) == tokFile {
+			return file
+		}
+	}
+	return nil
+}
+
+func getStmtList(node ast.Node) []ast.Stmt {
+	switch v := node.(type) {
+	case *ast.BlockStmt:
+		return v.List
+	case *ast.CaseClause:
+		return v.Body
+	case *ast.CommClause:
+		return v.Body
+	}
+	return nil
+}
+
+func getNextStmt(list []ast.Stmt, stmt ast.Stmt) ast.Stmt {
+	for i, el := range list {
+		if el == stmt && i+1 < len(list) {
+			return list[i+1]
+		}
+	}
+	return nil
+}
+
+// getStmtAt returns the innermost statement starting at pos, or nil if there's none.
+func getStmtAt(file *ast.File, pos token.Pos) ast.Stmt {
+	path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+
+	for _, node := range path {
+		if stmt, ok := node.(ast.Stmt); ok && stmt.Pos() == pos {
+			return stmt
+		}
+	}
+	return nil
+}
+
+// getInitPos returns the position where the init clause should be inserted into the statement,
+// or token.NoPos if the statement isn't the one at stmtPos or already has an init clause.
+func getInitPos(stmt ast.Stmt, stmtPos token.Pos) token.Pos {
+	switch v := stmt.(type) {
+	case *ast.IfStmt:
+		if v.If == stmtPos && v.Init == nil {
+			return v.Cond.Pos()
+		}
+	case *ast.SwitchStmt:
+		if v.Switch == stmtPos && v.Init == nil && v.Tag != nil {
+			return v.Tag.Pos()
+		}
+	case *ast.TypeSwitchStmt:
+		if v.Switch == stmtPos && v.Init == nil {
+			return v.Assign.Pos()
+		}
+	}
+	return token.NoPos
+}
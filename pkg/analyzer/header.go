@@ -0,0 +1,58 @@
+package analyzer
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// closeToMaxIfLineChars is the share of max-if-line-chars, starting from which the predicted length
+// of the header is shown in the message, to warn that the statement is close to be too long for short syntax.
+const closeToMaxIfLineChars = 0.9
+
+// getHeaderLength returns the length of the header the statement of the occurrence would have with the declaration
+// moved into its init clause, e.g. `if v := getValue(); v != nil {`, as printed by go/printer from the AST nodes.
+// The indentation isn't counted, and if the header spans several lines, the longest one is measured.
+// If the header can't be predicted, 0 is returned.
+func getHeaderLength(pass *analysis.Pass, meter *lengthMeter, occ occurrence) int {
+	file, assignment, _ := getDeclaration(pass, occ)
+	if assignment == nil {
+		return 0
+	}
+
+	stmt := getStmtAt(file, occ.ifStmtPos)
+	if getInitPos(stmt, occ.ifStmtPos) == token.NoPos {
+		return 0
+	}
+
+	// The statement is printed with an empty body, so that only the header and the closing brace are left.
+	var header ast.Stmt
+	switch v := stmt.(type) {
+	case *ast.IfStmt:
+		header = &ast.IfStmt{If: v.If, Init: assignment, Cond: v.Cond, Body: &ast.BlockStmt{}}
+	case *ast.SwitchStmt:
+		header = &ast.SwitchStmt{Switch: v.Switch, Init: assignment, Tag: v.Tag, Body: &ast.BlockStmt{}}
+	case *ast.TypeSwitchStmt:
+		header = &ast.TypeSwitchStmt{Switch: v.Switch, Init: assignment, Assign: v.Assign, Body: &ast.BlockStmt{}}
+	}
+
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	if err := cfg.Fprint(&buf, pass.Fset, header); err != nil {
+		return 0
+	}
+
+	text := strings.TrimSuffix(strings.TrimSpace(buf.String()), "}")
+
+	longest := 0
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if length := meter.measureText([]byte(line)); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Modes of measuring the length of code.
+const (
+	// LengthModeBytes measures the length in bytes.
+	LengthModeBytes = "bytes"
+	// LengthModeRunes measures the length in Unicode code points, so that multibyte characters count once.
+	LengthModeRunes = "runes"
+	// LengthModeColumns measures the length in visual columns: like runes, but with tabs expanded to the next tab stop.
+	LengthModeColumns = "columns"
+)
+
+// lengthMeter measures the length of code according to decl-length-mode.
+// The sources of the files are read on the first access, unless the length is measured in bytes.
+type lengthMeter struct {
+	pass     *analysis.Pass
+	mode     string
+	tabWidth int
+	sources  map[*token.File][]byte
+}
+
+func (cfg *Config) getLengthMeter(pass *analysis.Pass) (*lengthMeter, error) {
+	switch cfg.DeclLengthMode {
+	case "", LengthModeBytes, LengthModeRunes:
+	case LengthModeColumns:
+		if cfg.TabWidth < 1 {
+			return nil, fmt.Errorf("invalid tab-width: %d", cfg.TabWidth)
+		}
+	default:
+		return nil, fmt.Errorf("invalid decl-length-mode: %q", cfg.DeclLengthMode)
+	}
+
+	return &lengthMeter{
+		pass:     pass,
+		mode:     cfg.DeclLengthMode,
+		tabWidth: cfg.TabWidth,
+		sources:  map[*token.File][]byte{},
+	}, nil
+}
+
+// measure returns the length of the code between the positions.
+func (m *lengthMeter) measure(from, to token.Pos) int {
+	if m.mode == "" || m.mode == LengthModeBytes {
+		return int(to - from)
+	}
+
+	src := m.getSource(from)
+	if src == nil {
+		return int(to - from)
+	}
+
+	tokFile := m.pass.Fset.File(from)
+	start, end := tokFile.Offset(from), tokFile.Offset(to)
+
+	// The tab stops depend on the column the code starts at, so it's measured from the start of the line.
+	lineStart := tokFile.Offset(tokFile.LineStart(tokFile.Line(from)))
+	return m.measureText(src[lineStart:end]) - m.measureText(src[lineStart:start])
+}
+
+// measureText returns the length of the text. For a text spanning several lines,
+// it's the sum of the lengths of the lines, with the line breaks counted as a single character.
+func (m *lengthMeter) measureText(text []byte) int {
+	switch m.mode {
+	case LengthModeRunes:
+		return utf8.RuneCount(text)
+	case LengthModeColumns:
+		length, column := 0, 0
+		for _, r := range string(text) {
+			switch r {
+			case '\t':
+				width := m.tabWidth - column%m.tabWidth
+				length += width
+				column += width
+			case '\n':
+				length++
+				column = 0
+			default:
+				length++
+				column++
+			}
+		}
+		return length
+	}
+	return len(text)
+}
+
+// getSource returns the source of the file at pos, or nil if it can't be read.
+func (m *lengthMeter) getSource(pos token.Pos) []byte {
+	tokFile := m.pass.Fset.File(pos)
+
+	src, ok := m.sources[tokFile]
+	if !ok {
+		src, _ = readFile(m.pass, tokFile.Name())
+		if len(src) != tokFile.Size() {
+			src = nil
+		}
+		m.sources[tokFile] = src
+	}
+	return src
+}
+
+// getLineLengthWithInit returns the length of the longest line that the statement of the occurrence would have
+// with the declaration moved into its init clause: the line starting with the statement keyword, with the init clause
+// inserted. The lines of the declaration following the first one, if any, are measured too. If it can't be predicted, 0 is returned.
+func (m *lengthMeter) getLineLengthWithInit(occ occurrence) int {
+	file, assignment, _ := getDeclaration(m.pass, occ)
+	if assignment == nil {
+		return 0
+	}
+
+	initPos := getInitPos(getStmtAt(file, occ.ifStmtPos), occ.ifStmtPos)
+	if initPos == token.NoPos {
+		return 0
+	}
+
+	init, err := formatInit(m.pass.Fset, assignment)
+	if err != nil {
+		return 0
+	}
+
+	src := m.getSource(initPos)
+	if src == nil {
+		return 0
+	}
+
+	offset := m.pass.Fset.File(initPos).Offset(initPos)
+
+	lineStart := strings.LastIndexByte(string(src[:offset]), '\n') + 1
+	lineEnd := len(src)
+	if i := strings.IndexByte(string(src[offset:]), '\n'); i >= 0 {
+		lineEnd = offset + i
+	}
+
+	line := string(src[lineStart:offset]) + string(init) + "; " + string(src[offset:lineEnd])
+
+	longest := 0
+	for _, l := range strings.Split(line, "\n") {
+		if length := m.measureText([]byte(l)); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
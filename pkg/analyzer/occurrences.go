@@ -3,7 +3,7 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
-	"time"
+	"go/types"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -12,6 +12,8 @@ import (
 type occurrence struct {
 	declarationPos token.Pos
 	ifStmtPos      token.Pos
+	// stmtTok is the keyword of the statement at ifStmtPos, either token.IF or token.SWITCH.
+	stmtTok token.Token
 }
 
 func (occ *occurrence) isComplete() bool {
@@ -19,10 +21,12 @@ func (occ *occurrence) isComplete() bool {
 }
 
 // scopeMarkeredOccurences is a map of scope markers to variable occurrences.
-type scopeMarkeredOccurences map[int64]occurrence
+// Scope marker is the position of the assignment that declared the variable,
+// which is unique within the file set and thus never shared by two assignments.
+type scopeMarkeredOccurences map[token.Pos]occurrence
 
-func (smo scopeMarkeredOccurences) getGreatestMarker() int64 {
-	var maxScopeMarker int64
+func (smo scopeMarkeredOccurences) getGreatestMarker() token.Pos {
+	var maxScopeMarker token.Pos
 
 	for marker := range smo {
 		if marker > maxScopeMarker {
@@ -33,8 +37,8 @@ func (smo scopeMarkeredOccurences) getGreatestMarker() int64 {
 }
 
 // find scope marker of the greatest token.Pos that is smaller than provided.
-func (smo scopeMarkeredOccurences) getScopeMarkerForPosition(pos token.Pos) int64 {
-	var m int64
+func (smo scopeMarkeredOccurences) getScopeMarkerForPosition(pos token.Pos) token.Pos {
+	var m token.Pos
 	var foundPos token.Pos
 
 	for marker, occ := range smo {
@@ -59,50 +63,82 @@ func (smo scopeMarkeredOccurences) isEmponymousKey(pos token.Pos) bool {
 	return false
 }
 
-// namedOccurrenceMap is a map of variable names to scopeMarkeredOccurences.
-type namedOccurrenceMap map[string]scopeMarkeredOccurences
+// objectOccurrenceMap is a map of variables to scopeMarkeredOccurences.
+type objectOccurrenceMap map[types.Object]scopeMarkeredOccurences
 
-func getNamedOccurrenceMap(fdecl *ast.FuncDecl, pass *analysis.Pass) namedOccurrenceMap {
-	nom := namedOccurrenceMap(map[string]scopeMarkeredOccurences{})
+func getObjectOccurrenceMap(body *ast.BlockStmt, pass *analysis.Pass, cfg *Config, filter *varFilter, meter *lengthMeter) objectOccurrenceMap {
+	oom := objectOccurrenceMap(map[types.Object]scopeMarkeredOccurences{})
 
-	if fdecl == nil || fdecl.Body == nil {
-		return nom
+	if body == nil {
+		return oom
 	}
 
-	for _, stmt := range fdecl.Body.List {
-		switch v := stmt.(type) {
-		case *ast.AssignStmt:
-			nom.addFromAssignment(pass, v)
-		case *ast.IfStmt:
-			nom.addFromCondition(v)
-			nom.addFromIfClause(v)
-			nom.addFromElseClause(v)
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch v := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BlockStmt:
+			oom.addFromStmtList(pass, cfg, filter, meter, v.List)
+		case *ast.CaseClause:
+			oom.addFromStmtList(pass, cfg, filter, meter, v.Body)
+		case *ast.CommClause:
+			oom.addFromStmtList(pass, cfg, filter, meter, v.Body)
 		}
-	}
+		return true
+	})
 
-	candidates := namedOccurrenceMap(map[string]scopeMarkeredOccurences{})
+	candidates := objectOccurrenceMap(map[types.Object]scopeMarkeredOccurences{})
 
-	for varName, markeredOccs := range nom {
+	for obj, markeredOccs := range oom {
 		for marker, occ := range markeredOccs {
-			if !occ.isComplete() && !nom.isFoundByScopeMarker(marker) {
+			if !occ.isComplete() && !oom.isFoundByScopeMarker(marker) {
 				continue
 			}
-			if _, ok := candidates[varName]; !ok {
-				candidates[varName] = scopeMarkeredOccurences{
+			if _, ok := candidates[obj]; !ok {
+				candidates[obj] = scopeMarkeredOccurences{
 					marker: occ,
 				}
 			} else {
-				candidates[varName][marker] = occ
+				candidates[obj][marker] = occ
 			}
 		}
 	}
 	return candidates
 }
 
-func (nom namedOccurrenceMap) isFoundByScopeMarker(scopeMarker int64) bool {
+// addFromStmtList adds occurrences of the variables declared in the statement list,
+// matching them only with the if- and switch-statements of the same list.
+func (oom objectOccurrenceMap) addFromStmtList(pass *analysis.Pass, cfg *Config, filter *varFilter, meter *lengthMeter, list []ast.Stmt) {
+	blockOom := objectOccurrenceMap(map[types.Object]scopeMarkeredOccurences{})
+
+	for _, stmt := range list {
+		switch v := stmt.(type) {
+		case *ast.AssignStmt:
+			blockOom.addFromAssignment(pass, cfg, filter, meter, v)
+		case *ast.IfStmt:
+			blockOom.addFromCondition(pass, v)
+			blockOom.addFromIfClause(pass, v)
+			blockOom.addFromElseClause(pass, v)
+		case *ast.SwitchStmt:
+			if cfg.CheckSwitch {
+				blockOom.addFromSwitchTag(pass, v)
+			}
+		case *ast.TypeSwitchStmt:
+			if cfg.CheckSwitch {
+				blockOom.addFromTypeSwitchAssign(pass, v)
+			}
+		}
+	}
+
+	for obj, markeredOccs := range blockOom {
+		oom[obj] = markeredOccs
+	}
+}
+
+func (oom objectOccurrenceMap) isFoundByScopeMarker(scopeMarker token.Pos) bool {
 	var i int
 
-	for _, markeredOccs := range nom {
+	for _, markeredOccs := range oom {
 		for marker := range markeredOccs {
 			if marker == scopeMarker {
 				i++
@@ -112,12 +148,12 @@ func (nom namedOccurrenceMap) isFoundByScopeMarker(scopeMarker int64) bool {
 	return i >= 2
 }
 
-func (nom namedOccurrenceMap) addFromAssignment(pass *analysis.Pass, assignment *ast.AssignStmt) {
-	if assignment.Tok != token.DEFINE {
+func (oom objectOccurrenceMap) addFromAssignment(pass *analysis.Pass, cfg *Config, filter *varFilter, meter *lengthMeter, assignment *ast.AssignStmt) {
+	if assignment.Tok != token.DEFINE || isUnshortenableAssignment(assignment) {
 		return
 	}
 
-	scopeMarker := time.Now().UnixNano()
+	scopeMarker := assignment.Pos()
 
 	for i, el := range assignment.Lhs {
 		ident, ok := el.(*ast.Ident)
@@ -125,31 +161,40 @@ func (nom namedOccurrenceMap) addFromAssignment(pass *analysis.Pass, assignment
 			continue
 		}
 
-		if ident.Name == "_" || ident.Obj == nil || isUnshortenableAssignment(ident.Obj.Decl) {
+		if ident.Name == "_" {
 			continue
 		}
 
-		if markeredOccs, ok := nom[ident.Name]; ok {
-			markeredOccs[scopeMarker] = occurrence{
-				declarationPos: ident.Pos(),
+		// A redeclared variable starts a new scope of the object declared earlier.
+		obj := pass.TypesInfo.Defs[ident]
+		if obj == nil {
+			obj = pass.TypesInfo.Uses[ident]
+		}
+		if obj == nil {
+			continue
+		}
+
+		// The variables that aren't selected by the filter are still added, but never become complete occurrences.
+		selected := filter.isSelected(pass, assignment, i, obj)
+
+		if markeredOccs, ok := oom[obj]; ok {
+			occ := occurrence{}
+			if selected {
+				occ.declarationPos = ident.Pos()
 			}
-			nom[ident.Name] = markeredOccs
+			markeredOccs[scopeMarker] = occ
+			oom[obj] = markeredOccs
 		} else {
 			newOcc := occurrence{}
-			if areFlagSettingsSatisfied(pass, assignment, i) {
+			if selected && cfg.areFlagSettingsSatisfied(pass, meter, assignment, i) {
 				newOcc.declarationPos = ident.Pos()
 			}
-			nom[ident.Name] = scopeMarkeredOccurences{scopeMarker: newOcc}
+			oom[obj] = scopeMarkeredOccurences{scopeMarker: newOcc}
 		}
 	}
 }
 
-func isUnshortenableAssignment(decl interface{}) bool {
-	assign, ok := decl.(*ast.AssignStmt)
-	if !ok {
-		return false
-	}
-
+func isUnshortenableAssignment(assign *ast.AssignStmt) bool {
 	for _, el := range assign.Rhs {
 		u, ok := el.(*ast.UnaryExpr)
 		if !ok {
@@ -165,7 +210,7 @@ func isUnshortenableAssignment(decl interface{}) bool {
 	return false
 }
 
-func areFlagSettingsSatisfied(pass *analysis.Pass, assignment *ast.AssignStmt, i int) bool {
+func (cfg *Config) areFlagSettingsSatisfied(pass *analysis.Pass, meter *lengthMeter, assignment *ast.AssignStmt, i int) bool {
 	lh := assignment.Lhs[i]
 	rh := assignment.Rhs[len(assignment.Rhs)-1]
 
@@ -173,58 +218,61 @@ func areFlagSettingsSatisfied(pass *analysis.Pass, assignment *ast.AssignStmt, i
 		rh = assignment.Rhs[i]
 	}
 
-	if pass.Fset.Position(rh.End()).Line-pass.Fset.Position(rh.Pos()).Line > maxDeclLines {
-		return false
-	}
-	if int(rh.End()-lh.Pos()) > maxDeclChars {
-		return false
+	return !cfg.exceedsFlagSettings(pass, meter, lh, rh)
+}
+
+// exceedsFlagSettings checks if the code from the start of lh to the end of rh is longer
+// than allowed by max-decl-lines or max-decl-chars, measured according to decl-length-mode.
+func (cfg *Config) exceedsFlagSettings(pass *analysis.Pass, meter *lengthMeter, lh, rh ast.Node) bool {
+	if pass.Fset.Position(rh.End()).Line-pass.Fset.Position(rh.Pos()).Line > cfg.MaxDeclLines {
+		return true
 	}
-	return true
+	return meter.measure(lh.Pos(), rh.End()) > cfg.MaxDeclChars
 }
 
-func (nom namedOccurrenceMap) addFromCondition(stmt *ast.IfStmt) {
+func (oom objectOccurrenceMap) addFromCondition(pass *analysis.Pass, stmt *ast.IfStmt) {
 	switch v := stmt.Cond.(type) {
 	case *ast.BinaryExpr:
 		for _, v := range [2]ast.Expr{v.X, v.Y} {
 			switch e := v.(type) {
 			case *ast.CallExpr:
-				nom.addFromCallExpr(stmt.If, e)
+				oom.addFromCallExpr(pass, token.IF, stmt.If, e)
 			case *ast.Ident:
-				nom.addFromIdent(stmt.If, e)
+				oom.addFromIdent(pass, token.IF, stmt.If, e)
 			case *ast.SelectorExpr:
-				nom.addFromIdent(stmt.If, e.X)
+				oom.addFromIdent(pass, token.IF, stmt.If, e.X)
 			}
 		}
 	case *ast.CallExpr:
 		for _, a := range v.Args {
 			switch e := a.(type) {
 			case *ast.Ident:
-				nom.addFromIdent(stmt.If, e)
+				oom.addFromIdent(pass, token.IF, stmt.If, e)
 			case *ast.CallExpr:
-				nom.addFromCallExpr(stmt.If, e)
+				oom.addFromCallExpr(pass, token.IF, stmt.If, e)
 			}
 		}
 	case *ast.Ident:
-		nom.addFromIdent(stmt.If, v)
+		oom.addFromIdent(pass, token.IF, stmt.If, v)
 	case *ast.UnaryExpr:
 		switch e := v.X.(type) {
 		case *ast.Ident:
-			nom.addFromIdent(stmt.If, e)
+			oom.addFromIdent(pass, token.IF, stmt.If, e)
 		case *ast.SelectorExpr:
-			nom.addFromIdent(stmt.If, e.X)
+			oom.addFromIdent(pass, token.IF, stmt.If, e.X)
 		}
 	}
 }
 
-func (nom namedOccurrenceMap) addFromIfClause(stmt *ast.IfStmt) {
-	nom.addFromBlockStmt(stmt.Body, stmt.If)
+func (oom objectOccurrenceMap) addFromIfClause(pass *analysis.Pass, stmt *ast.IfStmt) {
+	oom.addFromBlockStmt(pass, stmt.Body, stmt.If)
 }
 
-func (nom namedOccurrenceMap) addFromElseClause(stmt *ast.IfStmt) {
-	nom.addFromBlockStmt(stmt.Else, stmt.If)
+func (oom objectOccurrenceMap) addFromElseClause(pass *analysis.Pass, stmt *ast.IfStmt) {
+	oom.addFromBlockStmt(pass, stmt.Else, stmt.If)
 }
 
-func (nom namedOccurrenceMap) addFromBlockStmt(stmt ast.Stmt, ifPos token.Pos) {
+func (oom objectOccurrenceMap) addFromBlockStmt(pass *analysis.Pass, stmt ast.Stmt, ifPos token.Pos) {
 	blockStmt, ok := stmt.(*ast.BlockStmt)
 	if !ok {
 		return
@@ -237,25 +285,53 @@ func (nom namedOccurrenceMap) addFromBlockStmt(stmt ast.Stmt, ifPos token.Pos) {
 		}
 
 		if callExpr, ok := exptStmt.X.(*ast.CallExpr); ok {
-			nom.addFromCallExpr(ifPos, callExpr)
+			oom.addFromCallExpr(pass, token.IF, ifPos, callExpr)
 		}
 	}
 }
 
-func (nom namedOccurrenceMap) addFromCallExpr(ifPos token.Pos, callExpr *ast.CallExpr) {
+func (oom objectOccurrenceMap) addFromSwitchTag(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	switch v := stmt.Tag.(type) {
+	case *ast.CallExpr:
+		oom.addFromCallExpr(pass, token.SWITCH, stmt.Switch, v)
+	case *ast.Ident:
+		oom.addFromIdent(pass, token.SWITCH, stmt.Switch, v)
+	case *ast.SelectorExpr:
+		oom.addFromIdent(pass, token.SWITCH, stmt.Switch, v.X)
+	}
+}
+
+func (oom objectOccurrenceMap) addFromTypeSwitchAssign(pass *analysis.Pass, stmt *ast.TypeSwitchStmt) {
+	var x ast.Expr
+
+	switch v := stmt.Assign.(type) {
+	case *ast.AssignStmt:
+		x = v.Rhs[0]
+	case *ast.ExprStmt:
+		x = v.X
+	}
+
+	if typeAssert, ok := x.(*ast.TypeAssertExpr); ok {
+		oom.addFromIdent(pass, token.SWITCH, stmt.Switch, typeAssert.X)
+	}
+}
+
+func (oom objectOccurrenceMap) addFromCallExpr(pass *analysis.Pass, tok token.Token, ifPos token.Pos, callExpr *ast.CallExpr) {
 	for _, arg := range callExpr.Args {
-		nom.addFromIdent(ifPos, arg)
+		oom.addFromIdent(pass, tok, ifPos, arg)
 	}
 }
 
-func (nom namedOccurrenceMap) addFromIdent(ifPos token.Pos, v ast.Expr) {
+func (oom objectOccurrenceMap) addFromIdent(pass *analysis.Pass, tok token.Token, ifPos token.Pos, v ast.Expr) {
 	ident, ok := v.(*ast.Ident)
 	if !ok {
 		return
 	}
 
-	if markeredOccs, ok := nom[ident.Name]; ok {
-		marker := nom[ident.Name].getGreatestMarker()
+	obj := pass.TypesInfo.Uses[ident]
+
+	if markeredOccs, ok := oom[obj]; ok {
+		marker := markeredOccs.getGreatestMarker()
 
 		occ := markeredOccs[marker]
 		if occ.isComplete() {
@@ -263,6 +339,7 @@ func (nom namedOccurrenceMap) addFromIdent(ifPos token.Pos, v ast.Expr) {
 		}
 
 		occ.ifStmtPos = ifPos
-		nom[ident.Name][marker] = occ
+		occ.stmtTok = tok
+		markeredOccs[marker] = occ
 	}
 }
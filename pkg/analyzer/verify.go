@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verifyFix checks that the package still type-checks after the fix is applied.
+// The files of the package are parsed anew from their sources, with the file the fix refers to rewritten in memory,
+// and type-checked against the already loaded imports.
+func verifyFix(pass *analysis.Pass, fix analysis.SuggestedFix) error {
+	tokFile := pass.Fset.File(fix.TextEdits[0].Pos)
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pass.Files))
+
+	for _, file := range pass.Files {
+		name := pass.Fset.File(file.Package).Name()
+
+		src, err := readFile(pass, name)
+		if err != nil {
+			return err
+		}
+
+		if name == tokFile.Name() {
+			if src, err = applyEdits(tokFile, src, fix.TextEdits); err != nil {
+				return err
+			}
+		}
+
+		f, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	imports := map[string]*types.Package{"unsafe": types.Unsafe}
+	for _, pkg := range pass.Pkg.Imports() {
+		imports[pkg.Path()] = pkg
+	}
+
+	conf := types.Config{
+		GoVersion: pass.Pkg.GoVersion(),
+		Sizes:     pass.TypesSizes,
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if pkg, ok := imports[path]; ok {
+				return pkg, nil
+			}
+			return nil, fmt.Errorf("package %q isn't imported by %s", path, pass.Pkg.Path())
+		}),
+	}
+
+	_, err := conf.Check(pass.Pkg.Path(), fset, files, nil)
+	return err
+}
+
+func readFile(pass *analysis.Pass, name string) ([]byte, error) {
+	if pass.ReadFile != nil {
+		return pass.ReadFile(name)
+	}
+	return os.ReadFile(name)
+}
+
+// applyEdits returns a copy of src with the edits applied. The edits must not overlap.
+func applyEdits(tokFile *token.File, src []byte, edits []analysis.TextEdit) ([]byte, error) {
+	edits = append([]analysis.TextEdit(nil), edits...)
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+
+	var out []byte
+	last := 0
+
+	for _, edit := range edits {
+		start, end := tokFile.Offset(edit.Pos), tokFile.Offset(edit.End)
+		if start < last || end < start || end > len(src) {
+			return nil, fmt.Errorf("invalid edit at %s", tokFile.Position(edit.Pos))
+		}
+
+		out = append(out, src[last:start]...)
+		out = append(out, edit.NewText...)
+		last = end
+	}
+	return append(out, src[last:]...), nil
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
@@ -0,0 +1,51 @@
+// Package plugin registers ifshort linter as a golangci-lint module plugin.
+//
+// To use it, add the plugin to .custom-gcl.yml:
+//
+//	plugins:
+//	  - module: github.com/esimonov/ifshort
+//	    import: github.com/esimonov/ifshort/pkg/plugin
+//	    version: latest
+//
+// and configure it in .golangci.yml, with the same settings as the flags of ifshort:
+//
+//	linters-settings:
+//	  custom:
+//	    ifshort:
+//	      type: module
+//	      settings:
+//	        max-decl-chars: 50
+//	        check-switch: true
+package plugin
+
+import (
+	"github.com/esimonov/ifshort/pkg/analyzer"
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+func init() {
+	register.Plugin(analyzer.Analyzer.Name, New)
+}
+
+type plugin struct {
+	cfg analyzer.Config
+}
+
+// New returns a new instance of the plugin, configured with the settings from golangci-lint configuration.
+// The settings that aren't set keep the values of analyzer.DefaultConfig.
+func New(settings any) (register.LinterPlugin, error) {
+	cfg := analyzer.DefaultConfig()
+	if err := cfg.Decode(settings); err != nil {
+		return nil, err
+	}
+	return &plugin{cfg: cfg}, nil
+}
+
+func (p *plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{analyzer.NewAnalyzer(p.cfg)}, nil
+}
+
+func (p *plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
@@ -0,0 +1,54 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/esimonov/ifshort/pkg/plugin"
+	"github.com/golangci/plugin-module-register/register"
+)
+
+func TestNew(t *testing.T) {
+	settings := map[string]any{
+		"max-decl-chars": 50,
+		"check-switch":   true,
+	}
+
+	p, err := plugin.New(settings)
+	if err != nil {
+		t.Fatalf("Failed to create plugin: %s", err)
+	}
+
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatalf("Failed to build analyzers: %s", err)
+	}
+	if len(analyzers) != 1 {
+		t.Fatalf("Expected a single analyzer, got %d", len(analyzers))
+	}
+
+	for name, expected := range map[string]string{
+		"max-decl-chars": "50",
+		"max-decl-lines": "1",
+		"check-switch":   "true",
+	} {
+		if value := analyzers[0].Flags.Lookup(name).Value.String(); value != expected {
+			t.Errorf("Expected %s to be %s, got %s", name, expected, value)
+		}
+	}
+
+	if p.GetLoadMode() != register.LoadModeTypesInfo {
+		t.Errorf("Expected load mode %s, got %s", register.LoadModeTypesInfo, p.GetLoadMode())
+	}
+}
+
+func TestNew_UnknownSetting(t *testing.T) {
+	if _, err := plugin.New(map[string]any{"max-decl-columns": 50}); err == nil {
+		t.Errorf("Expected an error for unknown setting")
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	if _, err := register.GetPlugin("ifshort"); err != nil {
+		t.Errorf("Expected the plugin to be registered: %s", err)
+	}
+}
@@ -0,0 +1,74 @@
+package directives
+
+func getValue(...interface{}) interface{} { return nil }
+
+func noOp(...interface{}) {}
+
+func notSuppressed_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func suppressed_OnDeclarationLine() {
+	v := getValue() //nolint:ifshort
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func suppressed_OnIfLine() {
+	v := getValue()
+	if v != nil { //ifshort:ignore
+		noOp(v)
+	}
+}
+
+func suppressed_ListOfLinters() {
+	v := getValue() //nolint:errcheck,ifshort // Kept separate for the breakpoint.
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func suppressed_BareNolint() {
+	v := getValue() //nolint
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func notSuppressed_OtherLinter_NotOK() {
+	v := getValue() //nolint:errcheck // want "variable '.+' is only used in the if-statement"
+	if v != nil {
+		noOp(v)
+	}
+}
+
+// suppressed_FuncLevel is suppressed as a whole.
+//
+//nolint:ifshort
+func suppressed_FuncLevel() {
+	v := getValue()
+	if v != nil {
+		noOp(v)
+	}
+
+	w := getValue()
+	if w != nil {
+		noOp(w)
+	}
+}
+
+func unused_OnDeclarationLine() {
+	v := getValue() //nolint:ifshort // want "directive '.+' is unused for ifshort"
+	noOp(v)
+}
+
+//ifshort:ignore // want "directive '.+' is unused for ifshort"
+func unused_FuncLevel() {}
+
+func unused_BareNolint() {
+	noOp() //nolint
+}
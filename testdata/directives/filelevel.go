@@ -0,0 +1,10 @@
+//ifshort:ignore
+
+package directives
+
+func suppressed_FileLevel() {
+	v := getValue()
+	if v != nil {
+		noOp(v)
+	}
+}
@@ -17,6 +17,7 @@ func getChan(...interface{}) chan interface{} { return nil }
 type dummyType struct {
 	interf interface{}
 	slice  []interface{}
+	flag   bool
 }
 
 func getDummy(...interface{}) dummyType { return dummyType{} }
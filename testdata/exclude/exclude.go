@@ -0,0 +1,66 @@
+package exclude
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+func noOp(...interface{}) {}
+
+func getMutex() *sync.Mutex { return nil }
+
+func withCancel(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Second)
+}
+
+func notUsed_ExcludedType_OK(ctx context.Context) {
+	_, cancel := withCancel(ctx)
+	if cancel != nil {
+		cancel()
+	}
+}
+
+func notUsed_ExcludedPointerType_OK() {
+	mu := getMutex()
+	if mu != nil {
+		noOp(mu)
+	}
+}
+
+func notUsed_ExcludedFunc_OK(ctx context.Context) {
+	ctx2 := context.Background()
+	if ctx2 != nil {
+		noOp(ctx2)
+	}
+}
+
+func notUsed_ExcludedMethod_OK(buf *bytes.Buffer) {
+	s := buf.String()
+	if s != "" {
+		noOp(s)
+	}
+}
+
+func notUsed_ExcludedInterfaceMethod_OK(r io.Reader, p []byte) {
+	_, err := r.Read(p)
+	if err != nil {
+		return
+	}
+}
+
+func notUsed_NotExcludedMethod_NotOK(buf *bytes.Buffer) {
+	n := buf.Len() // want "variable 'n' is only used in the if-statement"
+	if n != 0 {
+		noOp(n)
+	}
+}
+
+func notUsed_NotExcludedType_NotOK(ctx context.Context) {
+	err := ctx.Err() // want "variable 'err' is only used in the if-statement"
+	if err != nil {
+		return
+	}
+}
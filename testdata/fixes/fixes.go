@@ -0,0 +1,88 @@
+package fixes
+
+func getValue(...interface{}) interface{} { return nil }
+
+func getTwoValues(...interface{}) (interface{}, interface{}) { return nil, nil }
+
+func noOp(...interface{}) {}
+
+func fixed_Adjacent() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func fixed_BlankLineInBetween() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func fixed_CommentInBetween() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+
+	// Comment describing the if-statement.
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func fixed_BlankIdentifier(m map[string]interface{}) {
+	_, ok := m[""] // want "variable '.+' is only used in the if-statement"
+	if !ok {
+		return
+	}
+}
+
+func notFixed_IfHasInit() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if w := getValue(); v != nil {
+		noOp(w)
+	}
+}
+
+func notFixed_StatementInBetween() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	noOp()
+	if v != nil {
+		return
+	}
+}
+
+func fixed_CaseClause(n int) {
+	switch n {
+	case 0:
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp(v)
+		}
+	}
+}
+
+type dummy struct{ ok bool }
+
+func fixed_CompositeLiteral() {
+	d := dummy{} // want "variable '.+' is only used in the if-statement"
+	if !d.ok {
+		return
+	}
+}
+
+func fixed_MultilineDeclaration() {
+	/* want "variable '.+' is only used in the if-statement" */ v :=
+		getValue()
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func fixed_MultilineArguments() {
+	/* want "variable '.+' is only used in the if-statement" */ v := getValue(0,
+		1)
+	if v != nil {
+		noOp(v)
+	}
+}
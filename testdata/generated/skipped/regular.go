@@ -0,0 +1,12 @@
+package skipped
+
+func getValue(...interface{}) interface{} { return nil }
+
+func noOp(...interface{}) {}
+
+func regular_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if v != nil {
+		noOp(v)
+	}
+}
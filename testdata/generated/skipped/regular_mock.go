@@ -0,0 +1,8 @@
+package skipped
+
+func excluded_OK() {
+	v := getValue()
+	if v != nil {
+		noOp(v)
+	}
+}
@@ -0,0 +1,86 @@
+package generics
+
+func Map[T, U any](xs []T, f func(T) U) []U {
+	ys := make([]U, 0, len(xs))
+	for _, x := range xs {
+		ys = append(ys, f(x))
+	}
+	return ys
+}
+
+func First[T any](xs []T) (T, bool) {
+	var zero T
+	if len(xs) == 0 {
+		return zero, false
+	}
+	return xs[0], true
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func noOp(...interface{}) {}
+
+func itoa(int) string { return "" }
+
+func notUsed_GenericCall_NotOK(xs []int, f func(int) string) {
+	ys := Map[int, string](xs, f) // want "variable '.+' is only used in the if-statement"
+	if ys != nil {
+		noOp(ys)
+	}
+}
+
+func notUsed_InferredGenericCall_NotOK(xs []int) {
+	_, ok := First(xs) // want "variable '.+' is only used in the if-statement"
+	if !ok {
+		return
+	}
+}
+
+func notUsed_InGenericFunc_NotOK[T comparable](xs []T, x T) {
+	y, _ := First(xs) // want "variable '.+' is only used in the if-statement"
+	if y == x {
+		return
+	}
+}
+
+func notUsed_TypeParamValue_NotOK[T any](x T) {
+	p := Pair[string, T]{Value: x} // want "variable '.+' is only used in the if-statement"
+	if p.Key != "" {
+		noOp(p)
+	}
+}
+
+func notUsed_GenericCall_UsedAfterIf_OK(xs []int) []string {
+	ys := Map[int, string](xs, itoa)
+	if ys == nil {
+		return nil
+	}
+	return ys
+}
+
+func notUsed_InGenericFunc_UsedAfterIf_OK[T any](xs []T) T {
+	y, ok := First(xs)
+	if !ok {
+		return y
+	}
+	return y
+}
+
+func notUsed_InstantiatedFuncValue_OK(xs []int) []string {
+	f := Map[int, string]
+	if f == nil {
+		return nil
+	}
+	return f(xs, itoa)
+}
+
+func notUsed_TypeArgument_OK[T any](x T) {
+	p := Pair[string, T]{Value: x}
+	if p.Key != "" {
+		return
+	}
+	noOp(Pair[string, T]{Key: p.Key})
+}
@@ -0,0 +1,68 @@
+package header
+
+func getValue() interface{} { return nil }
+
+func isValid(...interface{}) bool { return true }
+
+func noOp(...interface{}) {}
+
+func shortHeader_NotOK() {
+	v := getValue() // want `variable 'v' is only used in the if-statement \(.*\); consider using short syntax$`
+	if v != nil {
+		noOp()
+	}
+}
+
+func closeToLimitHeader_NotOK(a interface{}) {
+	v := getValue() // want `variable 'v' is only used in the if-statement .* \(the if-line would be 38 characters long, max-if-line-chars is 40\)`
+	if isValid(v, a, 0) {
+		noOp()
+	}
+}
+
+func longHeader_OK(a, b interface{}) {
+	v := getValue()
+	if isValid(v, a, b, 0) {
+		noOp()
+	}
+}
+
+func longHeaderNotAdjacent_OK(a, b interface{}) {
+	v := getValue()
+	noOp()
+	if isValid(v, a, b, 0) {
+		noOp()
+	}
+}
+
+func closeToLimitHeaderNotAdjacent_NotOK(a interface{}) {
+	v := getValue() // want `variable 'v' is only used in the if-statement .* \(the if-line would be 38 characters long, max-if-line-chars is 40\)`
+	noOp()
+	if isValid(v, a, 0) {
+		noOp()
+	}
+}
+
+func multilineHeader_NotOK(a, b interface{}) {
+	v := getValue() // want `variable 'v' is only used in the if-statement \(.*\); consider using short syntax$`
+	if isValid(v, a,
+		b, 0) {
+		noOp()
+	}
+}
+
+func switchHeader_NotOK() {
+	v := getValue() // want `variable 'v' is only used in the switch-statement \(.*\); consider using short syntax$`
+	switch v {
+	case nil:
+		noOp()
+	}
+}
+
+func typeSwitchHeader_NotOK() {
+	v := getValue() // want `variable 'v' is only used in the switch-statement \(.*\); consider using short syntax$`
+	switch v.(type) {
+	case nil:
+		noOp()
+	}
+}
@@ -0,0 +1,71 @@
+package idiomatic
+
+type customError struct{}
+
+func (*customError) Error() string { return "" }
+
+func getValue() interface{} { return nil }
+
+func getError() error { return nil }
+
+func getCustomError() *customError { return nil }
+
+func getBool() (int, bool) { return 0, false }
+
+func noOp(...interface{}) {}
+
+func notUsed_Error_NotOK() {
+	e := getError() // want "variable 'e' is only used in the if-statement"
+	if e != nil {
+		return
+	}
+}
+
+func notUsed_CustomError_NotOK() {
+	e := getCustomError() // want "variable 'e' is only used in the if-statement"
+	if e != nil {
+		return
+	}
+}
+
+func notUsed_MapIndex_NotOK(m map[string]int) {
+	_, ok := m["k"] // want "variable 'ok' is only used in the if-statement"
+	if !ok {
+		return
+	}
+}
+
+func notUsed_TypeAssertion_NotOK(v interface{}) {
+	_, isString := v.(string) // want "variable 'isString' is only used in the if-statement"
+	if isString {
+		return
+	}
+}
+
+func notUsed_Receive_NotOK(ch chan int) {
+	_, open := <-ch // want "variable 'open' is only used in the if-statement"
+	if !open {
+		return
+	}
+}
+
+func notUsed_Value_OK() {
+	v := getValue()
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func notUsed_BoolResultOfCall_OK() {
+	_, ok := getBool()
+	if !ok {
+		return
+	}
+}
+
+func notUsed_SliceIndex_OK(s []bool) {
+	ok := s[0]
+	if !ok {
+		return
+	}
+}
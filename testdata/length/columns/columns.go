@@ -0,0 +1,18 @@
+package columns
+
+func noOp(...interface{}) {}
+
+func multilineWithTabs_OK() {
+	v := []int{
+		1, 2}
+	if len(v) != 0 {
+		noOp()
+	}
+}
+
+func singleLine_NotOK() {
+	v := []int{1, 2} // want "variable 'v' is only used in the if-statement"
+	if len(v) != 0 {
+		noOp()
+	}
+}
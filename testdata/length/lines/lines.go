@@ -0,0 +1,44 @@
+package lines
+
+func getValue() interface{} { return nil }
+
+func isValid(...interface{}) bool { return true }
+
+func noOp(...interface{}) {}
+
+func shortLine_NotOK() {
+	v := getValue() // want "variable 'v' is only used in the if-statement"
+	if v != nil {
+		noOp()
+	}
+}
+
+func longLine_OK(a, b interface{}) {
+	v := getValue()
+	if isValid(v, a, b) {
+		noOp()
+	}
+}
+
+func longLineNotAdjacent_OK(a, b interface{}) {
+	v := getValue()
+	noOp()
+	if isValid(v, a, b) {
+		noOp()
+	}
+}
+
+func shortLineNotAdjacent_NotOK() {
+	v := getValue() // want "variable 'v' is only used in the if-statement"
+	noOp()
+	if v != nil {
+		noOp()
+	}
+}
+
+func multibyteLine_NotOK() {
+	v := getValue() // want "variable 'v' is only used in the if-statement"
+	if v != "ё" {
+		noOp()
+	}
+}
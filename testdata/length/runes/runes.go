@@ -0,0 +1,17 @@
+package runes
+
+func noOp(...interface{}) {}
+
+func multibyteLiteral_NotOK() {
+	msg := "привет, мир!!!!" // want "variable 'msg' is only used in the if-statement"
+	if msg != "" {
+		noOp()
+	}
+}
+
+func multibyteLiteral_TooLong_OK() {
+	msg := "привет, мир и все, кто в нём!"
+	if msg != "" {
+		noOp()
+	}
+}
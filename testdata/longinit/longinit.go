@@ -0,0 +1,57 @@
+package longinit
+
+func getValue(...interface{}) interface{} { return nil }
+
+func getTwoValues(...interface{}) (interface{}, interface{}) { return nil, nil }
+
+func noOp(...interface{}) {}
+
+func shortInit_OK() {
+	if v := getValue(); v != nil {
+		noOp(v)
+	}
+}
+
+func longInit_NotOK() {
+	if v := getValue("Long long long declaration, it doesn't fit."); v != nil { // want "declaration of 'v' is too long for the init clause of if-statement"
+		noOp(v)
+	}
+}
+
+func highInit_NotOK() {
+	if a, b := getTwoValues( // want "declaration of 'a, b' is too long for the init clause of if-statement"
+		nil,
+		nil,
+		nil,
+	); a != nil {
+		noOp(b)
+	}
+}
+
+func longInit_ElseIf_OK() {
+	if v := getValue(); v != nil {
+		noOp(v)
+	} else if w := getValue("Long long long declaration, it doesn't fit."); w != nil {
+		noOp(w)
+	}
+}
+
+func longInit_Assignment_OK() {
+	var v interface{}
+	if v = getValue("Long long long declaration, it doesn't fit."); v != nil {
+		noOp(v)
+	}
+}
+
+func commaOkInit_OK(m map[string]interface{}) {
+	if _, ok := m["abcdefghijklmnopq"]; !ok {
+		return
+	}
+}
+
+func commaOk_NotOK(m map[string]interface{}) {
+	_, ok := m["abcdefghijklmnopq"] // want "variable 'ok' is only used in the if-statement"
+	if !ok {
+		return
+	}
+}
@@ -0,0 +1,357 @@
+package testdata
+
+import "unsafe"
+
+// Cases where a variable is referenced after the if-statement, one for each kind of ast.Stmt.
+
+func notUsed_Stmt_Decl_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	var w = v
+	noOp1(w)
+}
+
+func notUsed_Stmt_Decl_VarType_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	var a [unsafe.Sizeof(v)]int
+	noOp1(a)
+}
+
+func notUsed_Stmt_Decl_TypeSpec_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	type A [unsafe.Sizeof(v)]int
+	noOp1(A{})
+}
+
+func notUsed_Stmt_Labeled_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+LABEL:
+	noOp1(v)
+	goto LABEL
+}
+
+func notUsed_Stmt_Expr_OK() {
+	ch := getChan()
+	if ch != nil {
+		return
+	}
+	<-ch
+}
+
+func notUsed_Stmt_Send_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	getChan() <- v
+}
+
+func notUsed_Stmt_IncDec_OK() {
+	i := getInt()
+	if i != 0 {
+		return
+	}
+	i++
+}
+
+func notUsed_Stmt_Assign_Lhs_OK() {
+	m := map[interface{}]interface{}{}
+	v := getValue()
+	if v != nil {
+		return
+	}
+	m[v] = nil
+}
+
+func notUsed_Stmt_Assign_Rhs_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	w := v
+	noOp1(w)
+}
+
+func notUsed_Stmt_Go_OK() {
+	f := func() {}
+	if f == nil {
+		return
+	}
+	go f()
+}
+
+func notUsed_Stmt_Defer_OK() {
+	f := func() {}
+	if f == nil {
+		return
+	}
+	defer f()
+}
+
+func notUsed_Stmt_Return_OK() interface{} {
+	v := getValue()
+	if v != nil {
+		return nil
+	}
+	return v
+}
+
+func notUsed_Stmt_Block_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	{
+		noOp1(v)
+	}
+}
+
+func notUsed_Stmt_If_Init_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	if w := v; w != nil {
+		return
+	}
+}
+
+func notUsed_Stmt_If_CondIdent_OK() {
+	b := getBool()
+	if b {
+		return
+	}
+	if b {
+		return
+	}
+}
+
+func notUsed_Stmt_If_CondSelector_OK() {
+	d := getDummy()
+	if d.interf != nil {
+		return
+	}
+	if d.flag {
+		return
+	}
+}
+
+func notUsed_Stmt_If_ElseIf_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	if getBool() {
+		return
+	} else if v == nil {
+		return
+	}
+}
+
+func notUsed_Stmt_If_ElseIfBody_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	if getBool() {
+		return
+	} else if getBool() {
+		noOp1(v)
+	}
+}
+
+func notUsed_Stmt_Switch_Init_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	switch w := v; w {
+	}
+}
+
+func notUsed_Stmt_Switch_CaseList_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	switch getValue() {
+	case getValue(v):
+	}
+}
+
+func notUsed_Stmt_Switch_CaseBody_Return_OK() interface{} {
+	v := getValue()
+	if v != nil {
+		return nil
+	}
+	switch {
+	case getBool():
+		return v
+	}
+	return nil
+}
+
+func notUsed_Stmt_Switch_CaseBody_Send_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	switch {
+	case getBool():
+		getChan() <- v
+	}
+}
+
+func notUsed_Stmt_Switch_CaseBody_Defer_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	switch {
+	case getBool():
+		defer noOp1(v)
+	}
+}
+
+func notUsed_Stmt_TypeSwitch_Init_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	switch w := v; w.(type) {
+	}
+}
+
+func notUsed_Stmt_TypeSwitch_Assign_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	switch w := v.(type) {
+	case int:
+		noOp1(w)
+	}
+}
+
+func notUsed_Stmt_TypeSwitch_CaseBody_OK() interface{} {
+	v := getValue()
+	if v != nil {
+		return nil
+	}
+	switch getValue().(type) {
+	case int:
+		return v
+	}
+	return nil
+}
+
+func notUsed_Stmt_Select_Comm_OK() {
+	ch := getChan()
+	if ch != nil {
+		return
+	}
+	select {
+	case w := <-ch:
+		noOp1(w)
+	}
+}
+
+func notUsed_Stmt_Select_CommBody_OK() interface{} {
+	v := getValue()
+	if v != nil {
+		return nil
+	}
+	select {
+	case <-getChan():
+		return v
+	default:
+	}
+	return nil
+}
+
+func notUsed_Stmt_For_Init_OK() {
+	i := getInt()
+	if i != 0 {
+		return
+	}
+	for j := i; j < 10; j++ {
+	}
+}
+
+func notUsed_Stmt_For_Cond_OK() {
+	b := getBool()
+	if b {
+		return
+	}
+	for b {
+	}
+}
+
+func notUsed_Stmt_For_Post_OK() {
+	i := getInt()
+	if i != 0 {
+		return
+	}
+	for ; ; i++ {
+	}
+}
+
+func notUsed_Stmt_For_Body_OK() interface{} {
+	v := getValue()
+	if v != nil {
+		return nil
+	}
+	for {
+		return v
+	}
+}
+
+func notUsed_Stmt_Range_Key_OK() {
+	var k int
+	if k != 0 {
+		return
+	}
+	for k = range []int{} {
+	}
+}
+
+func notUsed_Stmt_Range_Value_OK() {
+	var v interface{}
+	v = getValue()
+	if v != nil {
+		return
+	}
+	for _, v = range []interface{}{} {
+	}
+}
+
+func notUsed_Stmt_Range_X_OK() {
+	s := getDummy().slice
+	if s == nil {
+		return
+	}
+	for range s {
+	}
+}
+
+func notUsed_Stmt_Range_Body_OK() interface{} {
+	v := getValue()
+	if v != nil {
+		return nil
+	}
+	for range []int{} {
+		return v
+	}
+	return nil
+}
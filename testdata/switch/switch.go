@@ -0,0 +1,87 @@
+package switchstmt
+
+func getValue(...interface{}) interface{} { return nil }
+
+func getInt(...interface{}) int { return 0 }
+
+func noOp(...interface{}) {}
+
+func notUsed_SwitchTag_NotOK() {
+	v := getInt() // want "variable '.+' is only used in the switch-statement"
+	switch v {
+	case 0:
+		noOp()
+	}
+}
+
+func notUsed_SwitchTagCall_NotOK() {
+	v := getInt() // want "variable '.+' is only used in the switch-statement"
+	switch getInt(v) {
+	case 0:
+	}
+}
+
+func notUsed_TypeSwitchGuard_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the switch-statement"
+	switch t := v.(type) {
+	case int:
+		noOp(t)
+	}
+}
+
+func notUsed_TypeSwitch_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the switch-statement"
+
+	switch v.(type) {
+	case int:
+	}
+}
+
+func notUsed_SwitchWithInit_NotOK() {
+	v := getInt() // want "variable '.+' is only used in the switch-statement"
+	switch w := getInt(); v {
+	case w:
+	}
+}
+
+func notUsed_SwitchInIf_NotOK() {
+	v := getInt() // want "variable '.+' is only used in the if-statement"
+	if v != 0 {
+		switch v {
+		case 1:
+		}
+	}
+}
+
+func notUsed_UsedInCaseBody_OK() {
+	v := getInt()
+	switch v {
+	case 0:
+		noOp(v)
+	}
+}
+
+func notUsed_UsedInCaseList_OK() {
+	v := getInt()
+	switch getInt() {
+	case v:
+	}
+}
+
+func notUsed_UsedAfterSwitch_OK() int {
+	v := getInt()
+	switch v {
+	case 0:
+	}
+	return v
+}
+
+func notUsed_UsedInIfAfterSwitch_OK() {
+	v := getInt()
+	switch v {
+	case 0:
+	}
+	if v != 0 {
+		return
+	}
+}
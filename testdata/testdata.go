@@ -70,6 +70,17 @@ func notUsed_Else_NotOK() {
 	}
 }
 
+func notUsed_ElseIf_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if v == nil {
+		noOp1(v)
+	} else if v != 0 {
+		noOp2(v)
+	} else {
+		noOp1(v)
+	}
+}
+
 func notUsed_DifferentVarsWithSameName_NotOK() {
 	_, b := getTwoValues() // want "variable '.+' is only used in the if-statement"
 	if b != nil {
@@ -296,6 +307,15 @@ func notUsed_MultipleAssignments_OK() interface{} {
 	return b
 }
 
+func notUsed_RedeclaredAfterIf_OK() {
+	a := getValue()
+	if a != nil {
+		return
+	}
+	a, b := getTwoValues()
+	noOp1(b)
+}
+
 func notUsed_MultipleAssignments_AllUsesInIfs_OK() interface{} {
 	a, b := getTwoValues()
 	if a != nil {
@@ -365,6 +385,24 @@ func notUsed_Pointer_OK() {
 	noOp1(&d)
 }
 
+func notUsed_PointerTakenBeforeIf_OK() {
+	v := getValue()
+	p := &v
+	if v != nil {
+		return
+	}
+	noOp1(*p)
+}
+
+func notUsed_MethodValueBeforeIf_OK() {
+	d := getDummy()
+	f := d.getValue
+	if d.interf != nil {
+		return
+	}
+	noOp1(f())
+}
+
 func notUsed_CondMethodCall_OK() {
 	d := dummyType{}
 	if d.getValue() == nil {
@@ -613,3 +651,204 @@ func notUsed_Also_Used_In_Else_Body_OK() {
 		noOp1(x)
 	}
 }
+
+func notUsed_ShadowedInClosure_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if v != nil {
+		noOp1(v)
+	}
+
+	func() {
+		v := getValue()
+		noOp2(v)
+	}()
+}
+
+func notUsed_ShadowedInIfBody_NotOK() {
+	err := getValue() // want "variable '.+' is only used in the if-statement"
+	if err != nil {
+		return
+	}
+
+	if getBool() {
+		err := getValue()
+		noOp1(err)
+	}
+}
+
+func notUsed_ShadowedInForBody_NotOK(n int) {
+	err := getValue() // want "variable '.+' is only used in the if-statement"
+	if err != nil {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		err := getValue(i)
+		noOp1(err)
+	}
+}
+
+func notUsed_BackToBackDeclarations_NotOK() {
+	a := getValue() // want "variable '.+' is only used in the if-statement"
+	b := getValue() // want "variable '.+' is only used in the if-statement"
+	c := getValue() // want "variable '.+' is only used in the if-statement"
+	d := getValue() // want "variable '.+' is only used in the if-statement"
+	if a != nil {
+		noOp1(a)
+	}
+	if b != nil {
+		noOp1(b)
+	}
+	if c != nil {
+		noOp1(c)
+	}
+	if d != nil {
+		noOp1(d)
+	}
+}
+
+func notUsed_NestedBlocks_NotOK(n int, ch chan interface{}) {
+	for i := 0; i < n; i++ {
+		v := getValue(i) // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	}
+
+	switch n {
+	case 0:
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	}
+
+	select {
+	case <-ch:
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	}
+
+	if n > 0 {
+	} else {
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	}
+
+	{
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	}
+}
+
+func notUsed_NestedBlocks_OK(n int, x interface{}) interface{} {
+	for i := 0; i < n; i++ {
+		v := getValue(i)
+		if v != nil {
+			noOp1(v)
+		}
+		noOp2(v)
+	}
+
+	switch n {
+	case 0:
+		v := getValue()
+		if v != nil {
+			noOp1(v)
+		}
+		return v
+	}
+
+	switch x.(type) {
+	case int:
+		v := getValue()
+		if v != nil {
+			noOp1(v)
+		}
+		return v
+	}
+
+	{
+		v := getValue()
+		if v != nil {
+			noOp1(v)
+		}
+		{
+			noOp2(v)
+		}
+	}
+	return nil
+}
+
+func notUsed_IfInNestedBlock_OK(n int) {
+	v := getValue()
+	if n > 0 {
+		if v != nil {
+			noOp1(v)
+		}
+	}
+}
+
+func notUsed_FuncLit_NotOK() {
+	run := func(f func()) { f() }
+
+	run(func() {
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	})
+
+	go func() {
+		v := getValue() // want "variable '.+' is only used in the if-statement"
+		if v != nil {
+			noOp1(v)
+		}
+	}()
+}
+
+func notUsed_FuncLit_CapturedInIf_NotOK() {
+	v := getValue() // want "variable '.+' is only used in the if-statement"
+	if v != nil {
+		go func() {
+			noOp1(v)
+		}()
+	}
+}
+
+func notUsed_FuncLit_Captured_OK() {
+	v := getValue()
+	if v != nil {
+		noOp1(v)
+	}
+
+	defer func() {
+		noOp2(v)
+	}()
+}
+
+func notUsed_FuncLit_CapturedInIf_OK() {
+	v := getValue()
+
+	func() {
+		if v != nil {
+			noOp1(v)
+		}
+	}()
+}
+
+func notUsed_FuncLit_UsedAfterIf_OK() {
+	func() {
+		v := getValue()
+		if v != nil {
+			noOp1(v)
+		}
+		noOp2(v)
+	}()
+}
@@ -0,0 +1,35 @@
+package vars
+
+func getValue() interface{} { return nil }
+
+func getError() error { return nil }
+
+func noOp(...interface{}) {}
+
+func notUsed_MatchingName_NotOK() {
+	err := getError() // want "variable 'err' is only used in the if-statement"
+	if err != nil {
+		return
+	}
+}
+
+func notUsed_MatchingCommaOk_NotOK(m map[string]int) {
+	_, ok := m["k"] // want "variable 'ok' is only used in the if-statement"
+	if !ok {
+		return
+	}
+}
+
+func notUsed_NotMatchingName_OK() {
+	v := getValue()
+	if v != nil {
+		noOp(v)
+	}
+}
+
+func notUsed_NotMatchingPrefix_OK() {
+	myerr := getError()
+	if myerr != nil {
+		return
+	}
+}
@@ -0,0 +1,24 @@
+package verify
+
+func getValue() interface{} { return nil }
+
+func getTwoValues() (interface{}, interface{}) { return nil, nil }
+
+func noOp(...interface{}) {}
+
+func fixed_NotOK() {
+	v := getValue() // want "variable 'v' is only used in the if-statement"
+	if v != nil {
+		noOp()
+	}
+}
+
+// Moving v would leave the second declaration of it unused, so it isn't reported with or without the verification.
+func notFixed_Redeclared_OK() {
+	v := getValue()
+	if v != nil {
+		return
+	}
+	v, w := getTwoValues()
+	noOp(w)
+}